@@ -7,6 +7,14 @@ var apple Apple
 const (
 	appleRadius   = 5
 	appleDiameter = appleRadius * 2
+
+	// The size of the area where apple centers can be placed.
+	appleAreaWidth  = firefly.Width - appleDiameter
+	appleAreaHeight = firefly.Height - hudHeight - appleDiameter
+
+	// How many places to try before giving up and placing the apple anyway.
+	// It's enough to check every place of the area once.
+	maxAppleTries = (appleAreaWidth/appleDiameter + 1) * (appleAreaHeight/appleDiameter + 1)
 )
 
 type Apple struct {
@@ -22,30 +30,61 @@ func newApple() Apple {
 
 // move the apple into a new place
 func (a *Apple) move() {
+	// Campaign stages may define where apples appear.
+	if pos, ok := run.applePos(); ok {
+		a.pos = pos
+		return
+	}
+	// Take exactly one random point per apple, so that the apple sequence
+	// of a daily challenge doesn't depend on where the snakes are.
 	pos := randomPoint()
-	// Don't place the apple inside the snake
-	for snakes.appleInside(pos) {
-		pos = randomPoint()
+	// Don't place the apple inside the snake or a wall
+	for range maxAppleTries {
+		if !snakes.appleInside(pos) && !run.appleInside(pos) {
+			break
+		}
+		pos = nextApplePoint(pos)
 	}
 	a.pos = pos
 }
 
-// Pick a random point for a new apple so that it's fully within the screen.
+// Get the next place to try for the apple if the given one is taken.
+//
+// Places go to the right in steps of the apple size and wrap to the next row,
+// so that repeated calls scan the whole play area.
+func nextApplePoint(p firefly.Point) firefly.Point {
+	x := p.X - appleRadius + appleDiameter
+	y := p.Y - hudHeight - appleRadius
+	if x >= appleAreaWidth {
+		x %= appleAreaWidth
+		y = (y + appleDiameter) % appleAreaHeight
+	}
+	return firefly.P(x+appleRadius, y+hudHeight+appleRadius)
+}
+
+// Pick a random point for a new apple so that it's fully within the screen
+// and isn't covered by the HUD.
 func randomPoint() firefly.Point {
-	x := int(firefly.GetRandom()%(firefly.Width-appleRadius*2)) + appleRadius
-	y := int(firefly.GetRandom()%(firefly.Height-appleRadius*2)) + appleRadius
+	x := int(gameRand.next()%appleAreaWidth) + appleRadius
+	y := int(gameRand.next()%appleAreaHeight) + hudHeight + appleRadius
 	return firefly.P(x, y)
 }
 
 func (a Apple) render() {
+	drawToroidalAround(a.pos, appleRadius, a.renderAt)
+}
+
+// Render the apple shifted by the given offset.
+func (a Apple) renderAt(shift firefly.Point) {
+	pos := a.pos.Add(shift)
 	firefly.DrawCircle(
-		firefly.Point{X: a.pos.X - appleRadius, Y: a.pos.Y - appleRadius},
+		firefly.Point{X: pos.X - appleRadius, Y: pos.Y - appleRadius},
 		appleDiameter,
 		firefly.Solid(firefly.ColorRed),
 	)
 	firefly.DrawLine(
-		a.pos,
-		firefly.Point{X: a.pos.X + appleRadius, Y: a.pos.Y - appleRadius},
+		pos,
+		firefly.Point{X: pos.X + appleRadius, Y: pos.Y - appleRadius},
 		firefly.LineStyle{Color: firefly.ColorGreen, Width: 3},
 	)
 }
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+func TestAppleMoveDrawsOnce(t *testing.T) {
+	oldRand, oldSnakes, oldRun := gameRand, snakes, run
+	t.Cleanup(func() { gameRand, snakes, run = oldRand, oldSnakes, oldRun })
+	run = nil
+
+	// Find where the apple would go and what the generator state is after that.
+	gameRand = newRand(42)
+	want := randomPoint()
+	after := gameRand
+
+	// Put the body of a snake right on that place.
+	gameRand = newRand(42)
+	snakes = &Snakes{items: []*Snake{testSnakeH(want.X+segmentLen*2, want.Y, 5)}}
+	if !snakes.appleInside(want) {
+		t.Fatalf("the snake doesn't cover %v", want)
+	}
+	a := Apple{}
+	a.move()
+
+	if gameRand != after {
+		t.Errorf("apple took more than one random point")
+	}
+	if a.pos == want {
+		t.Errorf("apple placed inside the snake at %v", a.pos)
+	}
+	if snakes.appleInside(a.pos) {
+		t.Errorf("apple moved to %v which is still inside the snake", a.pos)
+	}
+}
+
+func TestNextApplePoint(t *testing.T) {
+	const minX, minY = appleRadius, hudHeight + appleRadius
+	const maxX, maxY = minX + appleAreaWidth, minY + appleAreaHeight
+	cases := []struct {
+		name string
+		p    firefly.Point
+		want firefly.Point
+	}{
+		{"step right", firefly.P(50, 50), firefly.P(50+appleDiameter, 50)},
+		{"next row", firefly.P(maxX-1, 50), firefly.P(minX+appleDiameter-1, 50+appleDiameter)},
+		{"back to the top", firefly.P(maxX-1, maxY-1), firefly.P(minX+appleDiameter-1, minY+appleDiameter-1)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := nextApplePoint(c.p)
+			if got != c.want {
+				t.Errorf("nextApplePoint(%v) = %v, want %v", c.p, got, c.want)
+			}
+		})
+	}
+
+	// Repeated steps stay within the area where apples can be placed.
+	p := firefly.P(minX, minY)
+	for range maxAppleTries {
+		p = nextApplePoint(p)
+		if p.X < minX || p.X >= maxX || p.Y < minY || p.Y >= maxY {
+			t.Fatalf("apple point %v is out of the area", p)
+		}
+	}
+}
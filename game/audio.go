@@ -0,0 +1,146 @@
+package game
+
+import (
+	"github.com/firefly-zero/firefly-go/firefly/audio"
+)
+
+type Sound uint8
+
+// Sound effects.
+const (
+	// The snake ate the apple.
+	soundEat Sound = iota
+	// The snake bit itself or another snake.
+	soundBite
+	// The snake will soon lose points because of hunger.
+	soundHunger
+	// The snake split into two.
+	soundSplit
+	// Two halves of a split snake joined back together.
+	soundMerge
+	// The game is over or the local snake died.
+	soundGameOver
+	// The single-player game got to the next level.
+	soundLevelUp
+
+	// The number of sound effects. Must always go last.
+	nSounds
+)
+
+// A description of a synthesized sound effect.
+type sfx struct {
+	// The frequency at the start of the sound.
+	from audio.Hz
+	// The frequency at the end of the sound.
+	to audio.Hz
+	// How long the sound plays, in milliseconds.
+	ms uint32
+}
+
+var sounds = [nSounds]sfx{
+	soundEat:      {from: audio.C5, to: audio.C6, ms: 80},
+	soundBite:     {from: audio.A3, to: audio.A2, ms: 150},
+	soundHunger:   {from: audio.E4, to: audio.E4, ms: 100},
+	soundSplit:    {from: audio.G5, to: audio.G4, ms: 120},
+	soundMerge:    {from: audio.G4, to: audio.G5, ms: 120},
+	soundGameOver: {from: audio.C4, to: audio.C2, ms: 800},
+	soundLevelUp:  {from: audio.C5, to: audio.C7, ms: 300},
+}
+
+// The background music melody, a note per beat.
+var melody = [...]audio.Hz{
+	audio.C4, audio.E4, audio.G4, audio.E4,
+	audio.A3, audio.C4, audio.E4, audio.C4,
+	audio.F3, audio.A3, audio.C4, audio.A3,
+	audio.G3, audio.B3, audio.D4, audio.B3,
+}
+
+var (
+	// The root node for all game audio. Controls the volume.
+	master audio.Gain
+
+	// Sound effects channels, one per [Sound].
+	//
+	// Each sound has its own channel so that different sounds
+	// can play at the same time without cutting each other.
+	channels [nSounds]audio.Mix
+
+	// The channel for the background music.
+	music audio.Mix
+
+	// The index of the next note in the melody.
+	musicNote int
+
+	// How many frames are left before the next note of the music.
+	musicTimer int
+)
+
+// Build the audio graph.
+//
+// Must be called again when the volume settings change.
+func setupAudio() {
+	audio.Out.Clear()
+	master = audio.Out.AddGain(settings.gain())
+	for i := range channels {
+		channels[i] = master.AddMix()
+	}
+	music = master.AddMix()
+}
+
+// Play the given sound effect, interrupting the same sound if it's already playing.
+func playSound(s Sound) {
+	if settings.muted {
+		return
+	}
+	desc := sounds[s]
+	ch := channels[s]
+	ch.Clear()
+	gain := ch.AddGain(0)
+	gain.Modulate(audio.LinearModulator{
+		Start: .5,
+		End:   0,
+		EndAt: audio.MS(desc.ms),
+	})
+	osc := gain.AddSquare(desc.from, 0)
+	osc.Modulate(audio.LinearModulator{
+		Start: float32(desc.from),
+		End:   float32(desc.to),
+		EndAt: audio.MS(desc.ms),
+	})
+}
+
+// Play the next note of the background music when it's time.
+//
+// The less time the local snake can go without food, the faster the music plays.
+func updateMusic() {
+	if musicTimer > 0 {
+		musicTimer--
+		return
+	}
+	musicTimer = musicBeat()
+	note := melody[musicNote]
+	musicNote = (musicNote + 1) % len(melody)
+	if settings.muted {
+		return
+	}
+	music.Clear()
+	gain := music.AddGain(0)
+	gain.Modulate(audio.LinearModulator{
+		Start: .2,
+		End:   0,
+		EndAt: audio.MS(uint32(musicTimer) * 1000 / 60),
+	})
+	gain.AddTriangle(note, 0)
+}
+
+// The duration of one beat of the music, in frames.
+func musicBeat() int {
+	return 6 + int(hud.myScore().hungerPeriod())/30
+}
+
+// Stop the background music.
+func stopMusic() {
+	music.Clear()
+	musicNote = 0
+	musicTimer = 0
+}
@@ -9,7 +9,7 @@ type BBox struct {
 
 func newBBox(start, end firefly.Point, margin int) BBox {
 	left := start.ComponentMin(end)
-	right := start.ComponentMin(end)
+	right := start.ComponentMax(end)
 	left.X -= margin
 	right.X += margin
 	left.Y -= margin
@@ -2,19 +2,53 @@ package game
 
 import "github.com/firefly-zero/firefly-go/firefly"
 
+// Game modes that have their own leaderboards.
+type Mode uint8
+
 const (
-	singleplayer firefly.Board = 1
-	multiplayer  firefly.Board = 2
+	// The regular endless game.
+	modeEndless Mode = iota
+
+	// The endless game with the daily challenge code.
+	modeDaily
+
+	// The number of modes. Must always go last.
+	nModes
 )
 
-func updateLeaderBoard() {
-	board := singleplayer
-	if isMultiplayer {
-		board = multiplayer
+// The most players that have their own leaderboard.
+const maxBoardPlayers = 4
+
+// Leaderboards for each mode, indexed by the number of players minus one.
+//
+// Must match the boards in firefly.toml.
+var boards = [nModes][maxBoardPlayers]firefly.Board{
+	modeEndless: {1, 2, 4, 5},
+	modeDaily:   {3, 6, 7, 8},
+}
+
+// Post the best score of each player to the leaderboard for the current mode.
+//
+// Called once, when the match is over.
+// Campaign stages and games with too many players have no leaderboard.
+// Neither do games with custom rules, since their scores can't be compared
+// with classic games. Daily challenges always use the default rules (see [Rules.pin]).
+func submitScores() {
+	if run != nil || rules != (Rules{challenge: rules.challenge}) {
+		return
+	}
+	players := len(hud.scores)
+	if players > maxBoardPlayers {
+		return
+	}
+	mode := modeEndless
+	if rules.challenge != 0 {
+		mode = modeDaily
 	}
-	for _, snake := range snakes.items {
-		if snake.score.val != 0 {
-			firefly.AddScore(snake.peer, board, snake.score.val)
+	board := boards[mode][players-1]
+	for _, score := range hud.scores {
+		if score.best != 0 {
+			firefly.AddScore(score.peer, board, score.best)
 		}
 	}
 }
@@ -0,0 +1,33 @@
+package game
+
+import "testing"
+
+func TestSubmitScoresSkipsCustomRules(t *testing.T) {
+	oldRules, oldHUD, oldRun := rules, hud, run
+	t.Cleanup(func() { rules, hud, run = oldRules, oldHUD, oldRun })
+	run = nil
+	hud = &HUD{scores: []*Score{{best: 5}}}
+
+	cases := []struct {
+		name  string
+		rules Rules
+		want  int
+	}{
+		{"classic", Rules{}, 1},
+		{"daily challenge", Rules{challenge: 12}, 1},
+		{"respawn", Rules{respawn: true}, 0},
+		{"length score", Rules{lengthScore: true}, 0},
+		{"head-on bounce", Rules{headOn: headOnBounce}, 0},
+		{"hunger by time", Rules{hungerScale: hungerByTime}, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rules = c.rules
+			postedScores = 0
+			submitScores()
+			if postedScores != c.want {
+				t.Errorf("posted %d scores, want %d", postedScores, c.want)
+			}
+		})
+	}
+}
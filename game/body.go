@@ -0,0 +1,109 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The initial capacity of the body. Must be a power of two.
+const minBodyCap = 16
+
+// The snake's body: a sequence of segment points from the neck to the tail.
+//
+// The points are stored in a ring buffer, so moving the snake forward
+// and growing it doesn't need to touch all the points or allocate.
+type Body struct {
+	// The ring buffer. Its length is always a power of two.
+	segments []Segment
+
+	// The index in the buffer of the first point (the neck).
+	start int
+
+	// How many points the body has.
+	len int
+}
+
+func newBody(points ...firefly.Point) Body {
+	size := minBodyCap
+	for size < len(points) {
+		size *= 2
+	}
+	b := Body{segments: make([]Segment, size)}
+	for _, p := range points {
+		b.pushBack(p)
+	}
+	return b
+}
+
+// Get the segment at the given index, counting from the neck.
+func (b *Body) at(i int) *Segment {
+	return &b.segments[(b.start+i)&(len(b.segments)-1)]
+}
+
+// The first point of the body.
+func (b *Body) neck() firefly.Point {
+	return b.at(0).head
+}
+
+// Add a new point in front of the neck, making the body longer.
+func (b *Body) pushFront(p firefly.Point) {
+	b.reserve()
+	seq := b.at(0).seq - 1
+	b.start = (b.start - 1) & (len(b.segments) - 1)
+	b.len++
+	*b.at(0) = Segment{head: p, seq: seq}
+}
+
+// Add a new point after the tail, making the body longer.
+func (b *Body) pushBack(p firefly.Point) {
+	b.reserve()
+	var seq uint8
+	if b.len != 0 {
+		seq = b.at(b.len-1).seq + 1
+	}
+	b.len++
+	*b.at(b.len - 1) = Segment{head: p, seq: seq}
+}
+
+// Move the body forward: add a new point in front and drop the last one.
+func (b *Body) shift(p firefly.Point) {
+	seq := b.at(0).seq - 1
+	b.start = (b.start - 1) & (len(b.segments) - 1)
+	*b.at(0) = Segment{head: p, seq: seq}
+}
+
+// Make sure there is space for at least one more point.
+func (b *Body) reserve() {
+	if b.len < len(b.segments) {
+		return
+	}
+	segments := make([]Segment, len(b.segments)*2)
+	for i := range b.len {
+		segments[i] = *b.at(i)
+	}
+	b.segments = segments
+	b.start = 0
+}
+
+// Remove all points starting from the given index.
+func (b *Body) truncate(n int) {
+	b.len = min(b.len, n)
+}
+
+// Copy the points in the given index range into a new body.
+func (b *Body) slice(from, to int) Body {
+	size := minBodyCap
+	for size < to-from {
+		size *= 2
+	}
+	res := Body{segments: make([]Segment, size)}
+	for i := from; i < to; i++ {
+		res.segments[res.len] = *b.at(i)
+		res.len++
+	}
+	return res
+}
+
+// Add all points of the other body after the tail.
+func (b *Body) extend(other *Body) {
+	for i := range other.len {
+		b.pushBack(other.at(i).head)
+	}
+}
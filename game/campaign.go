@@ -0,0 +1,335 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+const (
+	// The ROM file with the description of all campaign stages.
+	stagesPath = "stages"
+
+	// The file in the app data dir where stars for each stage are stored.
+	starsPath = "stars"
+
+	// The width of walls in maze stages.
+	wallWidth = 3
+)
+
+// What the player needs to do to clear the stage.
+type Goal uint8
+
+const (
+	// Eat the target number of apples.
+	goalApples Goal = iota
+
+	// Stay split into at least two halves for the target number of seconds.
+	goalSplit
+)
+
+// A handcrafted challenge stage of the campaign.
+//
+// Stages are described in the stages ROM file, one stage per line.
+// Each line is a list of space-separated key=value pairs, for example:
+//
+//	apples=5 time=60 apple=40,40 apple=200,40 wall=120,30,120,130
+//
+// The keys are:
+//
+//   - apples: eat this many apples.
+//   - split: stay split for this many seconds.
+//   - time: the time limit, in seconds.
+//   - length: how many points the snake body starts with.
+//   - apple: the position of the next apple. Apples appear in the listed order.
+//   - wall: the start and the end of a wall.
+//
+// Empty lines and lines starting with "#" are skipped.
+type Stage struct {
+	goal Goal
+
+	// How many apples to eat or how many seconds to stay split.
+	target int
+
+	// The time limit, in seconds.
+	seconds int
+
+	// How many points the snake body starts with.
+	length int
+
+	// If not empty, apples appear at these positions, one after another.
+	apples []firefly.Point
+
+	walls []Line
+}
+
+var (
+	// All stages of the campaign loaded from the ROM.
+	stages []Stage
+
+	// The best rating (from 0 to 3) for each stage. Zero means the stage isn't cleared yet.
+	stars []byte
+
+	// The index of the stage to play. It's -1 for the regular endless game.
+	stageIndex = -1
+
+	// If not nil, a campaign stage is being played.
+	run *Run
+)
+
+func loadStages() []Stage {
+	raw := firefly.LoadFile(stagesPath, nil)
+	var res []Stage
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		stage, ok := parseStage(line)
+		if ok {
+			res = append(res, stage)
+		}
+	}
+	return res
+}
+
+// Parse the stage description from a line of the stages file.
+//
+// Unknown keys are ignored. A stage without a goal or a time limit is invalid.
+func parseStage(line string) (Stage, bool) {
+	s := Stage{length: minBodyLen, target: -1}
+	for _, field := range strings.Fields(line) {
+		key, value, _ := strings.Cut(field, "=")
+		nums, ok := parseInts(value)
+		if !ok {
+			return s, false
+		}
+		switch {
+		case key == "apples" && len(nums) == 1:
+			s.goal = goalApples
+			s.target = nums[0]
+		case key == "split" && len(nums) == 1:
+			s.goal = goalSplit
+			s.target = nums[0]
+		case key == "time" && len(nums) == 1:
+			s.seconds = nums[0]
+		case key == "length" && len(nums) == 1:
+			s.length = max(minBodyLen, nums[0])
+		case key == "apple" && len(nums) == 2:
+			s.apples = append(s.apples, firefly.P(nums[0], nums[1]))
+		case key == "wall" && len(nums) == 4:
+			wall := Line{h: firefly.P(nums[0], nums[1]), t: firefly.P(nums[2], nums[3])}
+			s.walls = append(s.walls, wall)
+		}
+	}
+	return s, s.target > 0 && s.seconds > 0
+}
+
+// Parse a comma-separated list of integers.
+func parseInts(s string) ([]int, bool) {
+	parts := strings.Split(s, ",")
+	res := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		res[i] = n
+	}
+	return res, true
+}
+
+func loadStars() []byte {
+	res := make([]byte, len(stages))
+	copy(res, firefly.LoadFile(starsPath, nil))
+	return res
+}
+
+// The state of the stage being played.
+type Run struct {
+	stage *Stage
+
+	// The index of the stage in the campaign.
+	index int
+
+	// How many apples are eaten or for how many frames the snake stayed split.
+	progress int
+
+	// The index of the next apple position from the stage description.
+	nextApple int
+
+	// How many stars the player got for the stage. Zero until the stage is cleared.
+	stars int
+
+	// If true, the stage is either cleared or failed.
+	done bool
+}
+
+// Start the stage with the given index.
+//
+// Returns nil if the index doesn't point to a stage.
+func newRun(index int) *Run {
+	if index < 0 || index >= len(stages) {
+		return nil
+	}
+	return &Run{stage: &stages[index], index: index}
+}
+
+// Prepare the snakes for the stage.
+func (r *Run) setup(ss *Snakes) {
+	if r == nil {
+		return
+	}
+	// Make the snake longer by adding points behind its tail.
+	for _, s := range ss.items {
+		for s.body.len < r.stage.length {
+			tail := s.body.at(s.body.len - 1).head
+			s.body.pushBack(firefly.P(normalizeX(tail.X-segmentLen), tail.Y))
+		}
+		s.updateShape(0)
+	}
+}
+
+// Check the win and lose conditions of the stage.
+func (r *Run) update(ss *Snakes) {
+	if r == nil || r.done {
+		return
+	}
+	// The game is already over.
+	if title != nil && title.blocking {
+		return
+	}
+	if r.stage.goal == goalSplit && ss.halves(hud.myScore()) >= 2 {
+		r.progress++
+	}
+	if r.progress >= r.goal() {
+		r.win()
+		return
+	}
+	if ticks >= r.stage.seconds*fps {
+		r.done = true
+		setTitle(msgStageFailed, true)
+	}
+}
+
+// The target value of the progress to clear the stage.
+func (r *Run) goal() int {
+	if r.stage.goal == goalSplit {
+		return r.stage.target * fps
+	}
+	return r.stage.target
+}
+
+// Finish the stage, save the rating, and pick the next stage to play.
+func (r *Run) win() {
+	r.done = true
+	// The faster the stage is cleared, the more stars it gives.
+	limit := r.stage.seconds * fps
+	switch {
+	case ticks*2 <= limit:
+		r.stars = 3
+	case ticks*4 <= limit*3:
+		r.stars = 2
+	default:
+		r.stars = 1
+	}
+	if r.stars > int(stars[r.index]) {
+		stars[r.index] = byte(r.stars)
+		firefly.DumpFile(starsPath, stars)
+	}
+	if r.index+1 < len(stages) {
+		stageIndex = r.index + 1
+	}
+	setTitle(msgStageClear, true)
+}
+
+// Count an eaten apple.
+func (r *Run) eat() {
+	if r == nil || r.stage.goal != goalApples {
+		return
+	}
+	r.progress++
+}
+
+// Pick the position for the next apple if the stage defines it.
+func (r *Run) applePos() (firefly.Point, bool) {
+	if r == nil || len(r.stage.apples) == 0 {
+		return firefly.Point{}, false
+	}
+	pos := r.stage.apples[r.nextApple]
+	r.nextApple = (r.nextApple + 1) % len(r.stage.apples)
+	return pos, true
+}
+
+// Check if an apple placed at the given point would collide with a wall.
+func (r *Run) appleInside(pos firefly.Point) bool {
+	if r == nil {
+		return false
+	}
+	const minDist = appleRadius + wallWidth
+	for _, w := range r.stage.walls {
+		if dist2ToLine(pos, w) <= minDist*minDist {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if the mouth of the snake hits a wall.
+func (r *Run) hitsWall(s *Snake) bool {
+	if r == nil {
+		return false
+	}
+	// Walls are placed in screen coordinates and never wrap.
+	// The neck starts at the mouth which is always on the screen.
+	neck := s.shapeLine(0)
+	for _, w := range r.stage.walls {
+		if thickIntersect(w, neck, (snakeWidth+wallWidth)/2) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Run) render() {
+	if r == nil {
+		return
+	}
+	for _, w := range r.stage.walls {
+		firefly.DrawLine(w.h, w.t, firefly.L(firefly.ColorDarkGray, wallWidth))
+	}
+}
+
+// Append to the buffer the stage progress and the time left.
+func (r *Run) appendStatus(dst []byte) []byte {
+	progress := r.progress
+	if r.stage.goal == goalSplit {
+		progress /= fps
+	}
+	dst = appendInt(dst, progress, 0, false)
+	dst = append(dst, '/')
+	dst = appendInt(dst, r.stage.target, 0, false)
+	dst = append(dst, ' ')
+	left := max(0, r.stage.seconds*fps-ticks)
+	return appendTime(dst, left)
+}
+
+// Append to the buffer the stage number and its rating as asterisks.
+//
+// The index -1 means the regular endless game.
+func appendStage(dst []byte, index int) []byte {
+	if index < 0 {
+		return append(dst, tr(msgFreePlay)...)
+	}
+	dst = appendInt(dst, index+1, 0, false)
+	dst = append(dst, ' ')
+	for i := range 3 {
+		if i < int(stars[index]) {
+			dst = append(dst, '*')
+		} else {
+			dst = append(dst, '.')
+		}
+	}
+	return dst
+}
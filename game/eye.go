@@ -6,8 +6,9 @@ import (
 )
 
 type Eye struct {
-	// The point the snake is looking at.
-	lookingAt firefly.Point
+	// The offset of the iris from the eye center.
+	// The snake is always looking at the apple.
+	look firefly.Point
 
 	// If true, the snake has bumped into another snake or itself on this update.
 	// Used to highlight the snake's eye with red.
@@ -20,32 +21,27 @@ type Eye struct {
 
 func (eye *Eye) update(mouth firefly.Point) {
 	// Calculate position of eye based on the where the apple is
-	lookX := float32(apple.pos.X - mouth.X)
-	lookY := float32(apple.pos.Y - mouth.Y)
+	look := wrapDelta(mouth, apple.pos)
+	lookX := float32(look.X)
+	lookY := float32(look.Y)
 	lookLen := tinymath.Hypot(lookX, lookY)
 	dX := lookX * 3 / lookLen
 	dY := lookY * 3 / lookLen
 
-	eye.lookingAt = firefly.Point{
-		X: mouth.X + int(dX),
-		Y: mouth.Y + int(dY),
-	}
+	eye.look = firefly.P(int(dX), int(dY))
 
-	eye.blinkCounter += int(firefly.GetRandom() % 5)
+	eye.blinkCounter += int(cosmeticRand.next() % 5)
 	if eye.blinkCounter > eye.blinkMaxTime {
 		eye.blinkCounter = 0
-		eye.blinkMaxTime = int(100 + firefly.GetRandom()%100)
+		eye.blinkMaxTime = int(100 + cosmeticRand.next()%100)
 	}
 }
 
-func (eye *Eye) render(mouth firefly.Point, me bool) {
+func (eye *Eye) render(mouth firefly.Point, me, starving bool) {
 	style := firefly.Solid(firefly.ColorWhite)
 	if eye.hurt {
 		style.FillColor = firefly.ColorRed
 	}
-	// We reset it only after rendering to make sure to render it
-	// for at least one frame.
-	eye.hurt = false
 
 	// Outer dark circle representing the head.
 	headColor := firefly.ColorBlue
@@ -86,17 +82,23 @@ func (eye *Eye) render(mouth firefly.Point, me bool) {
 	)
 
 	// Black circle representing the eye iris.
+	// A starving snake looks at the apple with wide open pupils.
+	irisSize := snakeWidth / 4
+	if starving {
+		irisSize = snakeWidth / 2
+	}
 	firefly.DrawCircle(
 		firefly.P(
-			eye.lookingAt.X-snakeWidth/8,
-			eye.lookingAt.Y-snakeWidth/8,
+			mouth.X+eye.look.X-irisSize/2,
+			mouth.Y+eye.look.Y-irisSize/2,
 		),
-		snakeWidth/4,
+		irisSize,
 		firefly.Solid(firefly.ColorBlack),
 	)
 
 	// If it's soon time to blink, close the eyelid.
-	if eye.blinkCounter < 20 {
+	// A starving snake is too focused on food to blink.
+	if eye.blinkCounter < 20 && !starving {
 		firefly.DrawCircle(
 			firefly.P(
 				mouth.X-snakeWidth/2+1,
@@ -0,0 +1,65 @@
+package game
+
+// Formatting numbers into byte buffers.
+//
+// All functions append to the given slice the same way as [strconv.AppendInt] does.
+// If the caller keeps a fixed-size array around and passes it as buf[:0],
+// formatting doesn't allocate, which matters when called on every frame.
+
+// The number of frames per second.
+const fps = 60
+
+// Append the decimal representation of the integer.
+//
+// The number is padded with leading zeros to have at least the given number of digits.
+// If sign is true, the plus sign is added to positive numbers.
+func appendInt(dst []byte, v int, pad int, sign bool) []byte {
+	if v < 0 {
+		dst = append(dst, '-')
+	} else if sign && v > 0 {
+		dst = append(dst, '+')
+	}
+
+	// Write digits right-to-left into a temporary buffer.
+	// It's big enough for any 64-bit integer.
+	var digits [20]byte
+	i := len(digits)
+	u := uint64(v)
+	if v < 0 {
+		u = uint64(-v)
+	}
+	for u != 0 || i == len(digits) {
+		i--
+		digits[i] = '0' + byte(u%10)
+		u /= 10
+	}
+	for n := len(digits) - i; n < pad; n++ {
+		dst = append(dst, '0')
+	}
+	return append(dst, digits[i:]...)
+}
+
+// Append the given duration in frames as minutes and seconds (mm:ss).
+func appendTime(dst []byte, frames int) []byte {
+	secs := frames / fps
+	dst = appendInt(dst, secs/60, 2, false)
+	dst = append(dst, ':')
+	return appendInt(dst, secs%60, 2, false)
+}
+
+// Append "on" or "off" in the current language.
+func appendBool(dst []byte, b bool) []byte {
+	if b {
+		return append(dst, tr(msgOn)...)
+	}
+	return append(dst, tr(msgOff)...)
+}
+
+// Append the daily challenge code formatted as "#123", or "off" if there is no challenge.
+func appendChallenge(dst []byte, code uint16) []byte {
+	if code == 0 {
+		return append(dst, tr(msgOff)...)
+	}
+	dst = append(dst, '#')
+	return appendInt(dst, int(code), 0, false)
+}
@@ -5,8 +5,10 @@ import (
 )
 
 var (
-	font   firefly.Font
-	frame  int
+	font  firefly.Font
+	frame int
+	// How many frames passed since the game started.
+	ticks  int
 	snakes *Snakes
 	title  *Title
 	me     firefly.Me
@@ -15,25 +17,54 @@ var (
 func Boot() {
 	font = firefly.LoadFile("font", nil).Font()
 	me = firefly.GetMe()
+	cosmeticRand = newRand(firefly.GetRandom())
+	settings = loadSettings()
+	setLanguage()
+	rules = loadRules()
+	bestLevel = loadBestLevel()
+	stages = loadStages()
+	stars = loadStars()
+	setupAudio()
 	resetGame()
 }
 
 func resetGame() {
+	gameRand = newRand(gameSeed())
 	snakes = newSnakes()
+	hud = newHUD(snakes)
+	run = newRun(stageIndex)
+	run.setup(snakes)
 	apple = newApple()
+	pellets = Pellets{}
+	resetLevel()
 	frame = 0
+	ticks = 0
 	title = nil
+	stopMusic()
 }
 
 func Update() {
+	if menu != nil {
+		menu.update()
+		return
+	}
 	if title != nil {
 		title.update()
 		if title != nil && title.blocking {
 			return
 		}
 	}
+	openMenu()
 	frame += 1
+	ticks += 1
 	snakes.update()
+	run.update(snakes)
+	// The match is over. It happens only once per match
+	// because the blocking title pauses the game until the restart.
+	if title != nil && title.blocking {
+		submitScores()
+	}
+	updateMusic()
 }
 
 func Render() {
@@ -44,8 +75,14 @@ func Render() {
 			return
 		}
 	}
+	run.render()
 	apple.render()
+	pellets.render()
 	snakes.render()
+	hud.render()
+	if menu != nil {
+		menu.render()
+	}
 }
 
 func Cheat(c, v int) int {
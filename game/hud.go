@@ -0,0 +1,131 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The height of the HUD bar at the top of the screen.
+const hudHeight = 12
+
+var hud *HUD
+
+// The heads-up display showing the state of all players.
+//
+// It's rendered on top of everything else in the game world.
+type HUD struct {
+	// The score of each player, in the order of peers.
+	//
+	// Scores are kept even when the snake dies
+	// so that the player still has a place in the HUD.
+	scores []*Score
+
+	// The buffer for formatting numbers.
+	buf [16]byte
+}
+
+func newHUD(ss *Snakes) *HUD {
+	scores := make([]*Score, len(ss.items))
+	for i, s := range ss.items {
+		scores[i] = s.score
+	}
+	return &HUD{scores: scores}
+}
+
+func (h *HUD) render() {
+	if h == nil {
+		return
+	}
+	firefly.DrawRect(
+		firefly.P(0, 0),
+		firefly.S(firefly.Width, hudHeight),
+		firefly.Solid(firefly.ColorWhite),
+	)
+	firefly.DrawLine(
+		firefly.P(0, hudHeight),
+		firefly.P(firefly.Width, hudHeight),
+		firefly.L(firefly.ColorLightGray, 1),
+	)
+	// The status goes first and the player entries share the space left of it.
+	// The status is rendered right away because entries reuse the buffer.
+	status := h.appendStatus(h.buf[:0])
+	statusWidth := 0
+	if len(status) != 0 {
+		h.renderStatus(status)
+		statusWidth = font.CharWidth()*len(status) + 4
+	}
+	entryWidth := (firefly.Width - statusWidth) / len(h.scores)
+	for i, score := range h.scores {
+		h.renderEntry(score, i*entryWidth, entryWidth)
+	}
+}
+
+// Append to the buffer the text for the right corner of the HUD.
+//
+// It's the campaign stage progress, the time left in a respawn match,
+// or the current level in single-player.
+func (h *HUD) appendStatus(dst []byte) []byte {
+	switch {
+	case run != nil:
+		return run.appendStatus(dst)
+	case rules.respawn:
+		left := max(0, respawnMatchTime-ticks)
+		return appendTime(dst, left)
+	case !isMultiplayer:
+		dst = append(dst, tr(msgLevel)...)
+		dst = append(dst, ' ')
+		return appendInt(dst, level, 0, false)
+	}
+	return dst
+}
+
+// Render the text in the right corner of the HUD.
+func (h *HUD) renderStatus(text []byte) {
+	x := firefly.Width - 2 - font.CharWidth()*len(text)
+	font.DrawBytes(text, firefly.P(x, font.CharHeight()), firefly.ColorBlack)
+}
+
+// Render the state of a single player in the given horizontal slot of the HUD.
+func (h *HUD) renderEntry(score *Score, x, width int) {
+	// The color of the player's snake.
+	c := palette(score.peer).base
+	firefly.DrawCircle(firefly.P(x+2, 2), snakeWidth, firefly.Solid(c))
+
+	// The score. Highlighted for a moment when it changes.
+	textColor := firefly.ColorBlack
+	if score.ttl != 0 {
+		textColor = score.color
+	}
+	textX := x + snakeWidth + 5
+	text := appendInt(h.buf[:0], int(score.val), 2, false)
+	font.DrawBytes(text, firefly.P(textX, font.CharHeight()), textColor)
+
+	if snakes.hasCrown(score) {
+		crownX := textX + font.CharWidth()*len(text) + 2
+		renderCrownAt(firefly.P(crownX+snakeWidth/2, 9))
+	}
+
+	// How long the snake can go without food.
+	if score.val != 0 {
+		barWidth := width - 4
+		filled := score.hungerLeft(barWidth)
+		barColor := firefly.ColorGreen
+		if score.starving() {
+			barColor = firefly.ColorRed
+		}
+		if filled > 0 {
+			firefly.DrawRect(
+				firefly.P(x+2, hudHeight-2),
+				firefly.S(filled, 2),
+				firefly.Solid(barColor),
+			)
+		}
+	}
+}
+
+// Get the score of the local player.
+func (h *HUD) myScore() *Score {
+	for _, score := range h.scores {
+		if me.Eq(score.peer) {
+			return score
+		}
+	}
+	return h.scores[0]
+}
@@ -0,0 +1,78 @@
+package game
+
+// The shape of the curve by which the hunger period gets shorter as the game goes.
+type HungerCurve uint8
+
+const (
+	// The period gets shorter by one frame on each step.
+	hungerLinear HungerCurve = iota
+
+	// The period gets shorter by a fraction of itself on each step.
+	hungerExponential
+
+	// The period never changes.
+	hungerConstant
+
+	// The number of curves. Must always go last.
+	nHungerCurves
+)
+
+// What makes the hunger period shorter.
+type HungerScale uint8
+
+const (
+	// Each point the snake gets for food is a step.
+	hungerByMeals HungerScale = iota
+
+	// Each few seconds of the game is a step.
+	hungerByTime
+
+	// The number of scales. Must always go last.
+	nHungerScales
+)
+
+const (
+	// The shortest possible hunger period, in frames.
+	minHungerPeriod = 10
+
+	// How many seconds of the game make one step when the hunger scales with time.
+	hungerStepSeconds = 2
+)
+
+// The hunger period at the start of the game, in frames.
+var hungerBase uint16
+
+// Get the hunger period (in frames) after the given number of steps.
+func (c HungerCurve) period(steps int) uint16 {
+	p := int(hungerBase)
+	switch c {
+	case hungerLinear:
+		p -= steps
+	case hungerExponential:
+		// Each step takes about 3% of the period.
+		for i := 0; i < steps && p > minHungerPeriod; i++ {
+			p -= p/32 + 1
+		}
+	}
+	return uint16(max(minHungerPeriod, p))
+}
+
+// Get the name of the hunger curve.
+func (c HungerCurve) name() Msg {
+	switch c {
+	case hungerExponential:
+		return msgHungerExponential
+	case hungerConstant:
+		return msgHungerConstant
+	default:
+		return msgHungerLinear
+	}
+}
+
+// Get the name of the hunger scale.
+func (s HungerScale) name() Msg {
+	if s == hungerByTime {
+		return msgHungerByTime
+	}
+	return msgHungerByMeals
+}
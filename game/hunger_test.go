@@ -0,0 +1,77 @@
+package game
+
+import "testing"
+
+// Set the hunger globals for a test and restore them after it.
+func setupHunger(t *testing.T, base uint16, curve HungerCurve, scale HungerScale) {
+	oldBase, oldRules, oldTicks := hungerBase, rules, ticks
+	hungerBase = base
+	rules = Rules{hungerCurve: curve, hungerScale: scale}
+	t.Cleanup(func() {
+		hungerBase, rules, ticks = oldBase, oldRules, oldTicks
+	})
+}
+
+func TestHungerCurvePeriod(t *testing.T) {
+	cases := []struct {
+		name  string
+		curve HungerCurve
+		steps int
+		want  uint16
+	}{
+		{"linear start", hungerLinear, 0, 300},
+		{"linear one step", hungerLinear, 1, 299},
+		{"linear many steps", hungerLinear, 100, 200},
+		{"linear clamped", hungerLinear, 295, minHungerPeriod},
+		{"linear far past the end", hungerLinear, 10000, minHungerPeriod},
+		{"exponential start", hungerExponential, 0, 300},
+		{"exponential one step", hungerExponential, 1, 290},
+		{"exponential three steps", hungerExponential, 3, 271},
+		{"exponential ten steps", hungerExponential, 10, 214},
+		{"exponential fifty steps", hungerExponential, 50, 49},
+		{"exponential clamped", hungerExponential, 100, minHungerPeriod},
+		{"constant start", hungerConstant, 0, 300},
+		{"constant many steps", hungerConstant, 10000, 300},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setupHunger(t, 300, c.curve, hungerByMeals)
+			got := c.curve.period(c.steps)
+			if got != c.want {
+				t.Errorf("period(%d) = %d, want %d", c.steps, got, c.want)
+			}
+		})
+	}
+}
+
+func TestHungerPeriodScale(t *testing.T) {
+	const step = hungerStepSeconds * fps
+	cases := []struct {
+		name  string
+		scale HungerScale
+		meals int
+		ticks int
+		want  uint16
+	}{
+		{"meals at start", hungerByMeals, 0, 0, 300},
+		{"meals ignore time", hungerByMeals, 0, 100 * step, 300},
+		{"meals count", hungerByMeals, 20, 0, 280},
+		{"time at start", hungerByTime, 0, 0, 300},
+		{"time ignores meals", hungerByTime, 20, 0, 300},
+		{"time before the first step", hungerByTime, 0, step - 1, 300},
+		{"time first step", hungerByTime, 0, step, 299},
+		{"time many steps", hungerByTime, 0, 50*step + step/2, 250},
+		{"time clamped", hungerByTime, 0, 1000 * step, minHungerPeriod},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setupHunger(t, 300, hungerLinear, c.scale)
+			ticks = c.ticks
+			s := Score{meals: c.meals}
+			got := s.hungerPeriod()
+			if got != c.want {
+				t.Errorf("hungerPeriod() = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The ID of a player-facing message.
+type Msg uint8
+
+const (
+	msgStarved Msg = iota
+	msgOtherStarved
+	msgBitSelf
+	msgOtherBitSelf
+	msgLose
+	msgWin
+	msgYou
+	msgBest
+	msgOn
+	msgOff
+	msgVolume
+	msgMute
+	msgHungerCue
+	msgLolcat
+	msgPressAnyButton
+	msgHeadOn
+	msgHeadOnBothHurt
+	msgHeadOnBiggerWins
+	msgHeadOnBounce
+	msgRespawn
+	msgLengthScore
+	msgHungerCurve
+	msgHungerLinear
+	msgHungerExponential
+	msgHungerConstant
+	msgHungerScale
+	msgHungerByMeals
+	msgHungerByTime
+	msgLevelUp
+	msgLevel
+	msgStage
+	msgFreePlay
+	msgStageClear
+	msgStageFailed
+	msgHitWall
+	msgChallenge
+	msgTimeUp
+
+	// The number of messages. Must always go last.
+	nMsgs
+)
+
+// A table of all messages in one language.
+type Dict [nMsgs]string
+
+// The default lolcat style of the game.
+var lolcat = Dict{
+	msgStarved:           "ur snek ded cuz its hungie :(",
+	msgOtherStarved:      "aze snek got hungie, u win",
+	msgBitSelf:           "u bit urself :(",
+	msgOtherBitSelf:      "other snek bit itself, u win",
+	msgLose:              "u lose :(",
+	msgWin:               "u win",
+	msgYou:               "you",
+	msgBest:              "bestest",
+	msgOn:                "ye",
+	msgOff:               "nah",
+	msgVolume:            "loudnes",
+	msgMute:              "shush",
+	msgHungerCue:         "hungie beep",
+	msgLolcat:            "lolspeak",
+	msgPressAnyButton:    "pres any butn",
+	msgHeadOn:            "bonk",
+	msgHeadOnBothHurt:    "both ouch",
+	msgHeadOnBiggerWins:  "big wins",
+	msgHeadOnBounce:      "boing",
+	msgRespawn:           "moar lives",
+	msgLengthScore:       "long = pointz",
+	msgHungerCurve:       "hungie",
+	msgHungerLinear:      "slow",
+	msgHungerExponential: "fast",
+	msgHungerConstant:    "nevr",
+	msgHungerScale:       "hungie from",
+	msgHungerByMeals:     "noms",
+	msgHungerByTime:      "clok",
+	msgLevelUp:           "lvl up!!1",
+	msgLevel:             "lvl",
+	msgStage:             "stej",
+	msgFreePlay:          "4evr",
+	msgStageClear:        "u did it!!",
+	msgStageFailed:       "2 slow :(",
+	msgHitWall:           "u bonkd a wal :(",
+	msgChallenge:         "dayli",
+	msgTimeUp:            "tiem iz up!",
+}
+
+// Standard English.
+var english = Dict{
+	msgStarved:           "Your snake starved to death :(",
+	msgOtherStarved:      "The other snake starved. You win!",
+	msgBitSelf:           "You bit yourself :(",
+	msgOtherBitSelf:      "The other snake bit itself. You win!",
+	msgLose:              "You lose :(",
+	msgWin:               "You win!",
+	msgYou:               "you",
+	msgBest:              "best",
+	msgOn:                "on",
+	msgOff:               "off",
+	msgVolume:            "volume",
+	msgMute:              "mute",
+	msgHungerCue:         "hunger cue",
+	msgLolcat:            "lolcat",
+	msgPressAnyButton:    "press any button",
+	msgHeadOn:            "head-on",
+	msgHeadOnBothHurt:    "both hurt",
+	msgHeadOnBiggerWins:  "bigger wins",
+	msgHeadOnBounce:      "bounce",
+	msgRespawn:           "respawn",
+	msgLengthScore:       "length score",
+	msgHungerCurve:       "hunger curve",
+	msgHungerLinear:      "linear",
+	msgHungerExponential: "exponential",
+	msgHungerConstant:    "constant",
+	msgHungerScale:       "hunger grows by",
+	msgHungerByMeals:     "meals",
+	msgHungerByTime:      "time",
+	msgLevelUp:           "Level up!",
+	msgLevel:             "lv",
+	msgStage:             "stage",
+	msgFreePlay:          "free play",
+	msgStageClear:        "Stage clear!",
+	msgStageFailed:       "Time is up :(",
+	msgHitWall:           "You hit a wall :(",
+	msgChallenge:         "daily code",
+	msgTimeUp:            "Time is up!",
+}
+
+// Translations for each supported system language.
+var dicts = map[firefly.Language]*Dict{
+	firefly.English: &english,
+}
+
+// The dictionary for the current language.
+var dict *Dict
+
+// Pick the dictionary based on the player's settings.
+//
+// The lolcat style, if enabled, takes precedence over the system language.
+// Languages without a translation fall back to English.
+func setLanguage() {
+	if settings.lolcat {
+		dict = &lolcat
+		return
+	}
+	lang := firefly.GetSettings(me).Language
+	d, ok := dicts[lang]
+	if !ok {
+		d = &english
+	}
+	dict = d
+}
+
+// Get the translation of the message into the current language.
+func tr(m Msg) string {
+	return dict[m]
+}
@@ -0,0 +1,52 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Get the height of the text wrapped to the given width.
+func textHeight(text string, maxWidth, lineHeight int) int {
+	nLines := 0
+	for rest := text; rest != ""; nLines++ {
+		_, rest = wrapLine(rest, maxWidth)
+	}
+	return nLines * lineHeight
+}
+
+// Draw the text wrapped to the given width, each line centered horizontally.
+//
+// The y is the top of the first line.
+// Returns the top of the line that would go right after the text.
+func drawWrapped(text string, y, maxWidth, lineHeight int, c firefly.Color) int {
+	for rest := text; rest != ""; y += lineHeight {
+		var line string
+		line, rest = wrapLine(rest, maxWidth)
+		x := (firefly.Width - font.LineWidth(line)) / 2
+		font.Draw(line, firefly.P(x, y+font.CharHeight()), c)
+	}
+	return y
+}
+
+// Cut from the text the longest line that fits into the given width.
+//
+// Lines are broken on spaces. A word that is too long to fit
+// is put on its own line and may overflow.
+func wrapLine(text string, maxWidth int) (string, string) {
+	end := 0
+	for i := 0; i <= len(text); i++ {
+		if i != len(text) && text[i] != ' ' {
+			continue
+		}
+		if end != 0 && font.LineWidth(text[:i]) > maxWidth {
+			break
+		}
+		end = i
+		if i == len(text) {
+			return text, ""
+		}
+	}
+	line := text[:end]
+	rest := text[end:]
+	for len(rest) != 0 && rest[0] == ' ' {
+		rest = rest[1:]
+	}
+	return line, rest
+}
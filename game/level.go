@@ -0,0 +1,72 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+const (
+	// How many frames it takes the snake to move by one segment on the first level.
+	basePeriod = 10
+
+	// How many apples the snake needs to eat to get to the next level.
+	applesPerLevel = 5
+
+	// The highest level. After it, the game doesn't get any faster.
+	maxLevel = 6
+
+	// The file in the app data dir where the best level is stored.
+	levelPath = "level"
+)
+
+var (
+	// How many frames it takes the snake to move by one segment.
+	//
+	// Gets shorter on higher levels, making snakes faster.
+	period = basePeriod
+
+	// The current difficulty level, starting from 1.
+	//
+	// Only the single-player endless game has levels,
+	// in multiplayer and campaign stages it's always 1.
+	level int
+
+	// How many apples were eaten since the game started.
+	levelApples int
+
+	// The highest level ever reached on this device.
+	bestLevel int
+)
+
+func loadBestLevel() int {
+	raw := firefly.LoadFile(levelPath, nil)
+	if len(raw) >= 1 {
+		return int(raw[0])
+	}
+	return 0
+}
+
+func resetLevel() {
+	level = 1
+	levelApples = 0
+	period = basePeriod
+}
+
+// Count an eaten apple and go to the next level when it's time.
+func countApple() {
+	if isMultiplayer || run != nil || level == maxLevel {
+		return
+	}
+	levelApples++
+	if levelApples%applesPerLevel != 0 {
+		return
+	}
+	level++
+	setBanner(msgLevelUp)
+	if level > bestLevel {
+		bestLevel = level
+		firefly.DumpFile(levelPath, []byte{byte(bestLevel)})
+	}
+}
+
+// How many frames it takes the snake to move by one segment on the current level.
+func levelPeriod() int {
+	return basePeriod - (level - 1)
+}
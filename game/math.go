@@ -1,8 +1,6 @@
 package game
 
 import (
-	"unsafe"
-
 	"github.com/firefly-zero/firefly-go/firefly"
 )
 
@@ -13,14 +11,109 @@ type Line struct {
 
 // Check if two segments intersect.
 //
-// Source: https://bryceboe.com/2006/10/23/line-segment-intersection-algorithm/
+// Segments touching each other by an endpoint or overlapping
+// on the same line are also considered intersecting.
+//
+// Source: https://www.geeksforgeeks.org/check-if-two-given-line-segments-intersect/
 func intersect(a, b Line) bool {
-	return ccw(a.h, b.h, b.t) != ccw(a.t, b.h, b.t) && ccw(a.h, a.t, b.h) != ccw(a.h, a.t, b.t)
+	o1 := orientation(a.h, a.t, b.h)
+	o2 := orientation(a.h, a.t, b.t)
+	o3 := orientation(b.h, b.t, a.h)
+	o4 := orientation(b.h, b.t, a.t)
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	// Collinear cases: a point of one segment lies on the other segment.
+	if o1 == 0 && onSegment(b.h, a) {
+		return true
+	}
+	if o2 == 0 && onSegment(b.t, a) {
+		return true
+	}
+	if o3 == 0 && onSegment(a.h, b) {
+		return true
+	}
+	if o4 == 0 && onSegment(a.t, b) {
+		return true
+	}
+	return false
 }
 
-// Check if the two points are in the counter-closckwise order.
-func ccw(a, b, c firefly.Point) bool {
-	return (c.Y-a.Y)*(b.X-a.X) > (b.Y-a.Y)*(c.X-a.X)
+// Get the orientation of the three points.
+//
+// It's 1 if they are in the counter-clockwise order, -1 if clockwise,
+// and 0 if they are on the same line.
+func orientation(a, b, c firefly.Point) int {
+	v := (c.Y-a.Y)*(b.X-a.X) - (b.Y-a.Y)*(c.X-a.X)
+	if v > 0 {
+		return 1
+	}
+	if v < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Check if the point collinear with the segment lies within the segment.
+func onSegment(p firefly.Point, l Line) bool {
+	lo := l.h.ComponentMin(l.t)
+	hi := l.h.ComponentMax(l.t)
+	return p.X >= lo.X && p.X <= hi.X && p.Y >= lo.Y && p.Y <= hi.Y
+}
+
+// Check if two segments drawn with the given width overlap.
+func thickIntersect(a, b Line, width int) bool {
+	return dist2Lines(a, b) <= width*width
+}
+
+// Get the squared distance between the closest points of two segments.
+func dist2Lines(a, b Line) int {
+	if intersect(a, b) {
+		return 0
+	}
+	return min(
+		dist2ToLine(a.h, b),
+		dist2ToLine(a.t, b),
+		dist2ToLine(b.h, a),
+		dist2ToLine(b.t, a),
+	)
+}
+
+// Get the squared distance from the point to the closest point of the line segment.
+func dist2ToLine(p firefly.Point, l Line) int {
+	dx := l.t.X - l.h.X
+	dy := l.t.Y - l.h.Y
+	px := p.X - l.h.X
+	py := p.Y - l.h.Y
+	len2 := dx*dx + dy*dy
+	if len2 == 0 {
+		return px*px + py*py
+	}
+	// Project the point on the line and clamp the projection to the segment.
+	t := float32(px*dx+py*dy) / float32(len2)
+	t = max(0, min(1, t))
+	cx := float32(px) - t*float32(dx)
+	cy := float32(py) - t*float32(dy)
+	return int(cx*cx + cy*cy)
+}
+
+// Get the point of the Catmull-Rom spline going from p1 to p2 at the given fraction (t) of the way.
+//
+// The p0 and p3 are the points before and after the curve, they define its bend.
+func catmullRom(p0, p1, p2, p3 firefly.Point, t float32) firefly.Point {
+	t2 := t * t
+	t3 := t2 * t
+	f := func(a, b, c, d int) int {
+		v := 2*float32(b) +
+			float32(c-a)*t +
+			float32(2*a-5*b+4*c-d)*t2 +
+			float32(-a+3*b-3*c+d)*t3
+		return int(v / 2)
+	}
+	return firefly.P(
+		f(p0.X, p1.X, p2.X, p3.X),
+		f(p0.Y, p1.Y, p2.Y, p3.Y),
+	)
 }
 
 // If x points outside the screen, shift it so that it's back on the screen.
@@ -33,39 +126,14 @@ func normalizeX(x int) int {
 	return x
 }
 
-// If y points outside the screen, shift it so that it's back on the screen.
+// If y points outside the play field, shift it so that it's back on the play field.
+//
+// The play field starts below the HUD.
 func normalizeY(y int) int {
 	if y >= firefly.Height {
-		y = y - firefly.Height
-	} else if y < 0 {
-		y += firefly.Height
+		y -= fieldHeight
+	} else if y < hudHeight {
+		y += fieldHeight
 	}
 	return y
 }
-
-// If the dots are on the opposite sides of the screen,
-// put the left one on the right outside the screen.
-func denormalizeX(start, end int) (int, int) {
-	if start-end > 30 {
-		end += firefly.Width
-	} else if end-start > 30 {
-		start += firefly.Width
-	}
-	return start, end
-}
-
-// If the dots are on the opposite sides of the screen,
-// put the upper one on the bottom outside the screen.
-func denormalizeY(start, end int) (int, int) {
-	if start-end > 30 {
-		end += firefly.Height
-	} else if end-start > 30 {
-		start += firefly.Height
-	}
-	return start, end
-}
-
-func formatInt(i int16) string {
-	buf := []byte{'0' + byte(i/10), '0' + byte(i%10)}
-	return unsafe.String(&buf[0], 2)
-}
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// Make a line from the coordinates of its start and end.
+func seg(x1, y1, x2, y2 int) Line {
+	return Line{firefly.P(x1, y1), firefly.P(x2, y2)}
+}
+
+func TestOrientation(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c firefly.Point
+		want    int
+	}{
+		{"collinear", firefly.P(0, 0), firefly.P(5, 5), firefly.P(10, 10), 0},
+		{"one way", firefly.P(0, 0), firefly.P(10, 0), firefly.P(10, 10), 1},
+		{"other way", firefly.P(0, 0), firefly.P(10, 0), firefly.P(10, -10), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orientation(tt.a, tt.b, tt.c)
+			if got != tt.want {
+				t.Errorf("orientation(%v, %v, %v) = %d, want %d", tt.a, tt.b, tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnSegment(t *testing.T) {
+	tests := []struct {
+		name string
+		p    firefly.Point
+		l    Line
+		want bool
+	}{
+		{"inside", firefly.P(5, 0), seg(0, 0, 10, 0), true},
+		{"start", firefly.P(0, 0), seg(0, 0, 10, 0), true},
+		{"end", firefly.P(10, 0), seg(0, 0, 10, 0), true},
+		{"reversed line", firefly.P(5, 5), seg(10, 10, 0, 0), true},
+		{"beyond the end", firefly.P(11, 0), seg(0, 0, 10, 0), false},
+		{"before the start", firefly.P(-1, 0), seg(0, 0, 10, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := onSegment(tt.p, tt.l)
+			if got != tt.want {
+				t.Errorf("onSegment(%v, %v) = %v, want %v", tt.p, tt.l, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Line
+		want bool
+	}{
+		{"crossing", seg(0, 0, 10, 10), seg(0, 10, 10, 0), true},
+		{"apart", seg(0, 0, 10, 0), seg(0, 5, 10, 20), false},
+		{"parallel not touching", seg(0, 0, 10, 0), seg(0, 1, 10, 1), false},
+		{"parallel diagonal not touching", seg(0, 0, 10, 10), seg(1, 0, 11, 10), false},
+		{"collinear overlapping", seg(0, 0, 10, 0), seg(5, 0, 15, 0), true},
+		{"collinear one inside another", seg(0, 0, 20, 0), seg(5, 0, 15, 0), true},
+		{"collinear touching by ends", seg(0, 0, 10, 0), seg(10, 0, 20, 0), true},
+		{"collinear apart", seg(0, 0, 10, 0), seg(11, 0, 20, 0), false},
+		{"endpoint touch", seg(0, 0, 10, 0), seg(10, 0, 10, 10), true},
+		{"endpoint touching the middle", seg(0, 0, 10, 0), seg(5, 0, 5, 10), true},
+		{"almost touching the middle", seg(0, 0, 10, 0), seg(5, 1, 5, 10), false},
+		{"point on line", seg(0, 0, 10, 0), seg(3, 0, 3, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := intersect(tt.a, tt.b); got != tt.want {
+				t.Errorf("intersect(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			// The order of segments must not matter.
+			if got := intersect(tt.b, tt.a); got != tt.want {
+				t.Errorf("intersect(%v, %v) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDist2Lines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Line
+		want int
+	}{
+		{"crossing", seg(0, 0, 10, 10), seg(0, 10, 10, 0), 0},
+		{"parallel", seg(0, 0, 10, 0), seg(0, 3, 10, 3), 9},
+		{"collinear apart", seg(0, 0, 10, 0), seg(14, 0, 20, 0), 16},
+		{"end to the middle", seg(0, 0, 10, 0), seg(5, 4, 5, 10), 16},
+		{"corner to corner", seg(0, 0, 10, 0), seg(13, 4, 20, 10), 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dist2Lines(tt.a, tt.b); got != tt.want {
+				t.Errorf("dist2Lines(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThickIntersect(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Line
+		width int
+		want  bool
+	}{
+		{"crossing, no width", seg(0, 0, 10, 10), seg(0, 10, 10, 0), 0, true},
+		{"parallel, closer than width", seg(0, 0, 10, 0), seg(0, 6, 10, 6), snakeWidth, true},
+		{"parallel, exactly at width", seg(0, 0, 10, 0), seg(0, snakeWidth, 10, snakeWidth), snakeWidth, true},
+		{"parallel, further than width", seg(0, 0, 10, 0), seg(0, snakeWidth+1, 10, snakeWidth+1), snakeWidth, false},
+		{"collinear, exactly at width", seg(0, 0, 10, 0), seg(10+snakeWidth, 0, 30, 0), snakeWidth, true},
+		{"collinear, further than width", seg(0, 0, 10, 0), seg(11+snakeWidth, 0, 30, 0), snakeWidth, false},
+		{"perpendicular, at width", seg(0, 0, 10, 0), seg(5, 3, 5, 10), 3, true},
+		{"perpendicular, beyond width", seg(0, 0, 10, 0), seg(5, 4, 5, 10), 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thickIntersect(tt.a, tt.b, tt.width); got != tt.want {
+				t.Errorf("thickIntersect(%v, %v, %d) = %v, want %v", tt.a, tt.b, tt.width, got, tt.want)
+			}
+		})
+	}
+}
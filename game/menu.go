@@ -0,0 +1,220 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// If not nil, the settings menu is open and the game is paused.
+var menu *Menu
+
+// The buttons state on the previous update while the menu is closed.
+// Used to open the menu only when the button is just pressed.
+var oldMenuBtns firefly.Buttons
+
+type Option struct {
+	name Msg
+
+	// Append the current value of the option to the buffer.
+	value func(dst []byte) []byte
+
+	// Change the value of the option. The direction is -1 or 1.
+	change func(dir int)
+}
+
+type Menu struct {
+	options []Option
+
+	// The index of the currently selected option.
+	cursor int
+
+	// The input state on the previous update.
+	// Used to react to a button only once when it's pressed.
+	oldPad  firefly.DPad4
+	oldBtns firefly.Buttons
+
+	// The stage selected when the menu was opened.
+	// If the player picks another one, the game restarts.
+	stage int
+
+	// The daily challenge code when the menu was opened.
+	// If the player picks another one, the game restarts.
+	challenge uint16
+
+	// The buffer for formatting option values.
+	buf [16]byte
+}
+
+func newMenu() *Menu {
+	return &Menu{
+		options: []Option{
+			{
+				name:  msgVolume,
+				value: func(dst []byte) []byte { return appendInt(dst, int(settings.volume), 0, false) },
+				change: func(dir int) {
+					settings.volume = uint8(max(0, min(maxVolume, int(settings.volume)+dir)))
+				},
+			},
+			{
+				name:   msgMute,
+				value:  func(dst []byte) []byte { return appendBool(dst, settings.muted) },
+				change: func(int) { settings.muted = !settings.muted },
+			},
+			{
+				name:   msgHungerCue,
+				value:  func(dst []byte) []byte { return appendBool(dst, settings.hungerCue) },
+				change: func(int) { settings.hungerCue = !settings.hungerCue },
+			},
+			{
+				name:  msgLolcat,
+				value: func(dst []byte) []byte { return appendBool(dst, settings.lolcat) },
+				change: func(int) {
+					settings.lolcat = !settings.lolcat
+					setLanguage()
+				},
+			},
+			{
+				name:  msgHeadOn,
+				value: func(dst []byte) []byte { return append(dst, tr(rules.headOn.name())...) },
+				change: ruleChange(func(dir int) {
+					rules.headOn = HeadOnRule(cycle(uint8(rules.headOn), uint8(nHeadOnRules), dir))
+				}),
+			},
+			{
+				name:   msgRespawn,
+				value:  func(dst []byte) []byte { return appendBool(dst, rules.respawn) },
+				change: ruleChange(func(int) { rules.respawn = !rules.respawn }),
+			},
+			{
+				name:   msgLengthScore,
+				value:  func(dst []byte) []byte { return appendBool(dst, rules.lengthScore) },
+				change: ruleChange(func(int) { rules.lengthScore = !rules.lengthScore }),
+			},
+			{
+				name:  msgStage,
+				value: func(dst []byte) []byte { return appendStage(dst, stageIndex) },
+				change: func(dir int) {
+					// The index -1 is the endless game, so there is one more value than stages.
+					n := len(stages) + 1
+					stageIndex = (stageIndex+1+dir+n)%n - 1
+					// Campaign stages and daily challenges don't mix.
+					rules.challenge = 0
+				},
+			},
+			{
+				name:  msgChallenge,
+				value: func(dst []byte) []byte { return appendChallenge(dst, rules.challenge) },
+				change: func(dir int) {
+					n := maxChallenge + 1
+					rules.challenge = uint16((int(rules.challenge) + dir + n) % n)
+					rules.pin()
+					stageIndex = -1
+				},
+			},
+			{
+				name:  msgHungerCurve,
+				value: func(dst []byte) []byte { return append(dst, tr(rules.hungerCurve.name())...) },
+				change: ruleChange(func(dir int) {
+					rules.hungerCurve = HungerCurve(cycle(uint8(rules.hungerCurve), uint8(nHungerCurves), dir))
+				}),
+			},
+			{
+				name:  msgHungerScale,
+				value: func(dst []byte) []byte { return append(dst, tr(rules.hungerScale.name())...) },
+				change: ruleChange(func(dir int) {
+					rules.hungerScale = HungerScale(cycle(uint8(rules.hungerScale), uint8(nHungerScales), dir))
+				}),
+			},
+		},
+		stage:     stageIndex,
+		challenge: rules.challenge,
+		// The menu is opened with a button, don't close it on the same press.
+		oldBtns: firefly.ReadButtons(firefly.Combined),
+	}
+}
+
+// Wrap the change of a rule so that rules can't be changed during a daily challenge.
+//
+// See [Rules.pin].
+func ruleChange(change func(int)) func(int) {
+	return func(dir int) {
+		if rules.challenge == 0 {
+			change(dir)
+		}
+	}
+}
+
+// Open the settings menu if the player asked for it.
+//
+// The menu pauses the game, so it's available only in single-player.
+func openMenu() {
+	if isMultiplayer {
+		return
+	}
+	btns := firefly.ReadButtons(firefly.Combined)
+	justPressed := btns.JustPressed(oldMenuBtns)
+	oldMenuBtns = btns
+	if justPressed.N {
+		menu = newMenu()
+	}
+}
+
+func (m *Menu) update() {
+	pad, _ := firefly.ReadPad(firefly.Combined)
+	dpad := pad.DPad4()
+	pressed := dpad
+	if dpad == m.oldPad {
+		pressed = firefly.DPad4None
+	}
+	m.oldPad = dpad
+	btns := firefly.ReadButtons(firefly.Combined)
+	justPressed := btns.JustPressed(m.oldBtns)
+	m.oldBtns = btns
+
+	switch pressed {
+	case firefly.DPad4Up:
+		m.cursor = (m.cursor + len(m.options) - 1) % len(m.options)
+	case firefly.DPad4Down:
+		m.cursor = (m.cursor + 1) % len(m.options)
+	case firefly.DPad4Left:
+		m.options[m.cursor].change(-1)
+	case firefly.DPad4Right:
+		m.options[m.cursor].change(1)
+	}
+	if justPressed.S {
+		m.options[m.cursor].change(1)
+	}
+	if justPressed.N || justPressed.E {
+		m.close()
+	}
+}
+
+// Close the menu and apply the new settings.
+func (m *Menu) close() {
+	menu = nil
+	// The button that closed the menu is still held, don't open it again.
+	oldMenuBtns = m.oldBtns
+	settings.save()
+	rules.save()
+	setupAudio()
+	if stageIndex != m.stage || rules.challenge != m.challenge {
+		resetGame()
+	}
+}
+
+func (m *Menu) render() {
+	const margin = 12
+	lineHeight := font.CharHeight() + 4
+	y := (firefly.Height - lineHeight*len(m.options)) / 2
+	x := margin + font.CharWidth()*2
+	for i, opt := range m.options {
+		c := firefly.ColorGray
+		if i == m.cursor {
+			c = firefly.ColorBlack
+			font.Draw(">", firefly.P(margin, y), c)
+		}
+		font.Draw(tr(opt.name), firefly.P(x, y), c)
+		// Values are aligned to the right.
+		value := opt.value(m.buf[:0])
+		valueX := firefly.Width - margin - font.CharWidth()*len(value)
+		font.DrawBytes(value, firefly.P(valueX, y), c)
+		y += lineHeight
+	}
+}
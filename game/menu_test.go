@@ -0,0 +1,50 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// The bit of the N button in the raw buttons state.
+const buttonN = 1 << 3
+
+func TestMenuOpensOnFreshPress(t *testing.T) {
+	oldMenu, oldBtns, oldMultiplayer := menu, oldMenuBtns, isMultiplayer
+	t.Cleanup(func() {
+		menu, oldMenuBtns, isMultiplayer = oldMenu, oldBtns, oldMultiplayer
+		heldButtons = 0
+	})
+	menu = nil
+	oldMenuBtns = firefly.Buttons{}
+	isMultiplayer = false
+
+	heldButtons = buttonN
+	openMenu()
+	if menu == nil {
+		t.Fatalf("menu isn't opened by N")
+	}
+
+	// Release N and press it again to close the menu.
+	heldButtons = 0
+	menu.update()
+	heldButtons = buttonN
+	menu.update()
+	if menu != nil {
+		t.Fatalf("menu isn't closed by N")
+	}
+
+	// N is still held on the next frame.
+	openMenu()
+	if menu != nil {
+		t.Fatalf("menu is opened again by the held N")
+	}
+
+	heldButtons = 0
+	openMenu()
+	heldButtons = buttonN
+	openMenu()
+	if menu == nil {
+		t.Fatalf("menu isn't opened by a new press of N")
+	}
+}
@@ -0,0 +1,41 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+type Pattern uint8
+
+const (
+	// Stripes of three shades of the snake's color.
+	patternStripes Pattern = iota
+
+	// The body of one color covered in dark scales.
+	patternScales
+
+	// The number of patterns. Must always go last.
+	nPatterns
+)
+
+// Shades of the snake's color.
+type Palette struct {
+	dark  firefly.Color
+	base  firefly.Color
+	light firefly.Color
+}
+
+// Get the colors of the snake of the given player.
+//
+// The local player's snake is always blue, everyone else is gray.
+func palette(peer firefly.Peer) Palette {
+	if me.Eq(peer) {
+		return Palette{
+			dark:  firefly.ColorDarkBlue,
+			base:  firefly.ColorBlue,
+			light: firefly.ColorLightBlue,
+		}
+	}
+	return Palette{
+		dark:  firefly.ColorDarkGray,
+		base:  firefly.ColorGray,
+		light: firefly.ColorLightGray,
+	}
+}
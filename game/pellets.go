@@ -0,0 +1,70 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// Small bits of food left behind by snakes.
+var pellets Pellets
+
+const (
+	pelletRadius   = 2
+	pelletDiameter = pelletRadius * 2
+
+	// How many pellets the snake needs to eat to get one point.
+	pelletsPerPoint = 3
+
+	// How many pellets can be on the screen at once.
+	// When there are more, the oldest ones disappear.
+	maxPellets = 64
+)
+
+type Pellet struct {
+	// Coordinates of the pellet center.
+	pos firefly.Point
+
+	color firefly.Color
+}
+
+type Pellets struct {
+	items []Pellet
+}
+
+// Place a new pellet at the given point.
+func (ps *Pellets) add(pos firefly.Point, c firefly.Color) {
+	if len(ps.items) == maxPellets {
+		copy(ps.items, ps.items[1:])
+		ps.items = ps.items[:maxPellets-1]
+	}
+	ps.items = append(ps.items, Pellet{pos: pos, color: c})
+}
+
+// Remove all pellets close enough to the mouth and return how many were eaten.
+func (ps *Pellets) eat(mouth firefly.Point) int {
+	const minDist = (pelletRadius+snakeWidth)/2 + 2
+	const minDist2 = minDist * minDist
+	left := ps.items[:0]
+	for _, p := range ps.items {
+		d := wrapDelta(mouth, p.pos)
+		if d.X*d.X+d.Y*d.Y > minDist2 {
+			left = append(left, p)
+		}
+	}
+	eaten := len(ps.items) - len(left)
+	ps.items = left
+	return eaten
+}
+
+func (ps *Pellets) render() {
+	for _, p := range ps.items {
+		drawToroidalAround(p.pos, pelletRadius, p.renderAt)
+	}
+}
+
+// Render the pellet shifted by the given offset.
+func (p Pellet) renderAt(shift firefly.Point) {
+	pos := p.pos.Add(shift)
+	firefly.DrawCircle(
+		firefly.P(pos.X-pelletRadius, pos.Y-pelletRadius),
+		pelletDiameter,
+		firefly.Solid(p.color),
+	)
+}
@@ -0,0 +1,55 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The highest daily challenge code. Codes are days of the year.
+const maxChallenge = 366
+
+// The random generator for everything that affects the gameplay.
+//
+// It's seeded at the start of each game, either from the runtime random
+// (which is the same for all peers) or from the daily challenge code.
+// So all players of the same challenge get the same apple sequence.
+var gameRand Rand
+
+// The random generator for things that only change how the game looks,
+// like blinking of eyes.
+//
+// It's seeded once on boot and is separate from [gameRand], so that changes
+// in cosmetic code never change where apples appear. Peers don't need to agree on it.
+var cosmeticRand Rand
+
+// A small deterministic pseudo-random number generator (xorshift32).
+type Rand struct {
+	state uint32
+}
+
+func newRand(seed uint32) Rand {
+	// Xorshift gets stuck on zero.
+	if seed == 0 {
+		seed = 1
+	}
+	return Rand{state: seed}
+}
+
+// Get the next random number.
+func (r *Rand) next() uint32 {
+	x := r.state
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	r.state = x
+	return x
+}
+
+// Pick the seed for the gameplay random generator for a new game.
+//
+// The runtime random must be used only here and on boot,
+// everything else should take numbers from [gameRand] or [cosmeticRand].
+func gameSeed() uint32 {
+	if rules.challenge != 0 {
+		// Spread the codes over the whole range of seeds.
+		return uint32(rules.challenge) * 2654435761
+	}
+	return firefly.GetRandom()
+}
@@ -0,0 +1,118 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// What happens when mouths of two snakes hit each other.
+type HeadOnRule uint8
+
+const (
+	// Both snakes lose points.
+	headOnBothHurt HeadOnRule = iota
+
+	// The snake with the lower score loses points. On a tie, both do.
+	headOnBiggerWins
+
+	// Nobody loses points but both snakes turn away.
+	headOnBounce
+
+	// The number of rules. Must always go last.
+	nHeadOnRules
+)
+
+var rules Rules
+
+// Gameplay rules.
+//
+// Unlike [Settings], rules affect the gameplay and so must be the same for all peers.
+// That's why they're stored in the stash which the runtime shares between devices.
+// In multiplayer, the rules of the first peer are used.
+// They can be changed only in single-player.
+type Rules struct {
+	headOn HeadOnRule
+
+	// If true, a player who lost gets back into the game after a delay.
+	respawn bool
+
+	// If true, the score is the length of the snake.
+	// Losing a part of the body, when bitten or split, costs points.
+	lengthScore bool
+
+	// How the hunger period of each snake gets shorter as the game goes.
+	hungerCurve HungerCurve
+
+	// What makes the hunger period shorter.
+	hungerScale HungerScale
+
+	// The code of the daily challenge, from 1 to [maxChallenge].
+	// All games with the same code get the same apples.
+	// Zero means there is no challenge.
+	challenge uint16
+}
+
+func loadRules() Rules {
+	r := Rules{}
+	var buf [8]byte
+	raw := firefly.LoadStash(rulesPeer(), buf[:])
+	if len(raw) >= 1 {
+		r.headOn = HeadOnRule(raw[0] % byte(nHeadOnRules))
+	}
+	if len(raw) >= 2 {
+		r.respawn = raw[1] != 0
+	}
+	if len(raw) >= 3 {
+		r.lengthScore = raw[2] != 0
+	}
+	if len(raw) >= 5 {
+		r.hungerCurve = HungerCurve(raw[3] % byte(nHungerCurves))
+		r.hungerScale = HungerScale(raw[4] % byte(nHungerScales))
+	}
+	if len(raw) >= 7 {
+		r.challenge = min(uint16(raw[5])|uint16(raw[6])<<8, maxChallenge)
+	}
+	r.pin()
+	return r
+}
+
+// Reset all rules to the defaults if a daily challenge is active.
+//
+// Everyone plays the same challenge by the same rules,
+// so that the scores on the daily leaderboards can be compared.
+func (r *Rules) pin() {
+	if r.challenge != 0 {
+		*r = Rules{challenge: r.challenge}
+	}
+}
+
+func (r Rules) save() {
+	firefly.SaveStash(rulesPeer(), []byte{
+		byte(r.headOn),
+		boolByte(r.respawn),
+		boolByte(r.lengthScore),
+		byte(r.hungerCurve),
+		byte(r.hungerScale),
+		byte(r.challenge),
+		byte(r.challenge >> 8),
+	})
+}
+
+// The peer whose stash stores the rules for the current game.
+func rulesPeer() firefly.Peer {
+	return firefly.GetPeers().Slice()[0]
+}
+
+// Get the name of the head-on collision rule.
+func (r HeadOnRule) name() Msg {
+	switch r {
+	case headOnBiggerWins:
+		return msgHeadOnBiggerWins
+	case headOnBounce:
+		return msgHeadOnBounce
+	default:
+		return msgHeadOnBothHurt
+	}
+}
+
+// Step through enum values in the given direction, wrapping around at the ends.
+func cycle(v, n uint8, dir int) uint8 {
+	return uint8((int(v) + dir + int(n)) % int(n))
+}
@@ -0,0 +1,25 @@
+package game
+
+import "testing"
+
+func TestRulesPin(t *testing.T) {
+	custom := Rules{
+		headOn:      headOnBounce,
+		respawn:     true,
+		lengthScore: true,
+		hungerCurve: hungerConstant,
+		hungerScale: hungerByTime,
+	}
+
+	r := custom
+	r.pin()
+	if r != custom {
+		t.Errorf("rules without a challenge changed: %+v", r)
+	}
+
+	r.challenge = 12
+	r.pin()
+	if r != (Rules{challenge: 12}) {
+		t.Errorf("rules of a challenge aren't the defaults: %+v", r)
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import "unsafe"
+
+// The SDK functions imported from the Firefly runtime have no body outside of wasm.
+// These stubs let the game logic run in regular Go tests on the host.
+
+//go:linkname addProgress github.com/firefly-zero/firefly-go/firefly.addProgress
+func addProgress(peerID, badgeID uint32, val int32) uint32 { return 0 }
+
+//go:linkname addGain github.com/firefly-zero/firefly-go/firefly/audio.addGain
+func addGain(parentID uint32, lvl float32) uint32 { return 0 }
+
+//go:linkname addSquare github.com/firefly-zero/firefly-go/firefly/audio.addSquare
+func addSquare(parentID uint32, freq float32, phase float32) uint32 { return 0 }
+
+//go:linkname modLinear github.com/firefly-zero/firefly-go/firefly/audio.modLinear
+func modLinear(nodeID uint32, param uint32, start float32, end float32, startAt uint32, endAt uint32) {
+}
+
+//go:linkname clearNode github.com/firefly-zero/firefly-go/firefly/audio.clearNode
+func clearNode(nodeID uint32) {}
+
+//go:linkname readPad github.com/firefly-zero/firefly-go/firefly.readPad
+func readPad(player uint32) int32 { return 0 }
+
+// The buttons the stubbed runtime reports as held, one bit per button.
+var heldButtons uint32
+
+//go:linkname readButtons github.com/firefly-zero/firefly-go/firefly.readButtons
+func readButtons(player uint32) uint32 { return heldButtons }
+
+// The scores posted to leaderboards through the stubbed runtime.
+var postedScores int
+
+//go:linkname addScore github.com/firefly-zero/firefly-go/firefly.addScore
+func addScore(peerID, boardID uint32, val int32) int32 {
+	postedScores++
+	return val
+}
+
+//go:linkname getRandom github.com/firefly-zero/firefly-go/firefly.getRandom
+func getRandom() uint32 { return 4 }
+
+// A single peer.
+//
+//go:linkname getPeers github.com/firefly-zero/firefly-go/firefly.getPeers
+func getPeers() uint32 { return 1 }
+
+//go:linkname dumpFile github.com/firefly-zero/firefly-go/firefly.dumpFile
+func dumpFile(pathPtr unsafe.Pointer, pathLen uint32, bufPtr unsafe.Pointer, bufLen uint32) uint32 {
+	return 0
+}
+
+//go:linkname saveStash github.com/firefly-zero/firefly-go/firefly.saveStash
+func saveStash(peerID uint32, bufPtr unsafe.Pointer, bufLen uint32) {}
+
+//go:linkname getSettings github.com/firefly-zero/firefly-go/firefly.getSettings
+func getSettings(index uint32) uint64 { return 0 }
+
+//go:linkname addMix github.com/firefly-zero/firefly-go/firefly/audio.addMix
+func addMix(parentID uint32) uint32 { return 0 }
@@ -14,8 +14,52 @@ const (
 	badgeEat100Apples firefly.Badge = 3
 )
 
-// How long (in frames) the snake can go without food.
-var hungerPeriod uint16
+// How many frames before the snake gets hungry the player is warned about it.
+const hungerWarning = 60
+
+// The reason why the player lost.
+type Death uint8
+
+const (
+	// The player is still in the game.
+	deathNone Death = iota
+	// The snake went too long without food.
+	deathStarved
+	// The snake bit itself.
+	deathBitSelf
+	// The snake bit another snake.
+	deathBitOther
+	// The snake hit a wall of a campaign stage.
+	deathHitWall
+)
+
+// The title to show when a player loses for this reason.
+//
+// The mine flag tells if it's the local player who lost.
+func (d Death) msg(mine bool) Msg {
+	switch d {
+	case deathStarved:
+		if mine {
+			return msgStarved
+		}
+		return msgOtherStarved
+	case deathBitSelf:
+		if mine {
+			return msgBitSelf
+		}
+		return msgOtherBitSelf
+	case deathHitWall:
+		if mine {
+			return msgHitWall
+		}
+		return msgWin
+	default:
+		if mine {
+			return msgLose
+		}
+		return msgWin
+	}
+}
 
 type Score struct {
 	peer firefly.Peer
@@ -23,6 +67,9 @@ type Score struct {
 	// The current score. Cannot go below zero.
 	val int16
 
+	// The highest score reached during the game.
+	best int16
+
 	// Invisibility frames.
 	// For how many frames from now the snake is invinsible.
 	iframes uint8
@@ -34,16 +81,52 @@ type Score struct {
 	ttl uint8
 
 	color firefly.Color
+
+	// The state of the player's buttons on the previous update.
+	//
+	// Stored in the score because it's shared by all halves of a split snake.
+	btns firefly.Buttons
+
+	// How many tail segments the snake should lose because of lost points.
+	//
+	// The tail shrinks by one segment on each shift.
+	// Shared by all halves of a split snake.
+	shrink int16
+
+	// How many times the snake got a point for food.
+	//
+	// Used to make the hunger period shorter as the snake eats.
+	meals int
+
+	// How many pellets the snake ate since it got the last point for them.
+	pellets uint8
+
+	// Why the player lost. It's [deathNone] while the player is in the game.
+	death Death
 }
 
 func newScore(peer firefly.Peer) *Score {
 	return &Score{
 		peer:    peer,
-		hunger:  hungerPeriod,
+		hunger:  hungerBase,
 		iframes: iFrames,
 	}
 }
 
+// Bring the score back to the initial state when the player respawns.
+//
+// The best score is kept.
+func (s *Score) reset() {
+	s.val = 0
+	s.shrink = 0
+	s.pellets = 0
+	s.meals = 0
+	s.hunger = hungerBase
+	s.iframes = iFrames
+	s.death = deathNone
+	s.ttl = 0
+}
+
 // update the score.
 //
 // Checks for collisions and iframes and decrements the score if needed.
@@ -59,36 +142,70 @@ func (s *Score) update() {
 		// before the snake eats the first apple.
 		if s.val != 0 {
 			s.dec()
-			s.hunger = hungerPeriod
+			s.hunger = s.hungerPeriod()
 			if s.val == 0 {
-				updateLeaderBoard()
-				snakes.deletePeer(s.peer)
-				gameOver := snakes.gameOver()
-				if me.Eq(s.peer) {
-					setTitle("ur snek ded cuz its hungie :(", gameOver)
-				} else if gameOver {
-					setTitle("aze snek got hungie, u win", gameOver)
-				}
+				// The snake is removed at the end of the update.
+				s.death = deathStarved
 			}
 		}
 	} else {
 		s.hunger--
+		// Warn the player a second before the snake gets hungry.
+		// Only the local player hears about their own snake.
+		if s.hunger == hungerWarning && s.val != 0 && settings.hungerCue && me.Eq(s.peer) {
+			playSound(soundHunger)
+		}
 	}
 	if s.ttl != 0 {
 		s.ttl--
 	}
 }
 
+// Check if the snake will soon lose points because of hunger.
+func (s *Score) starving() bool {
+	return s.val != 0 && s.hunger < hungerWarning
+}
+
+// How much of the hunger period is left, from 0 to the given max value.
+func (s *Score) hungerLeft(maxVal int) int {
+	period := s.hungerPeriod()
+	hunger := min(s.hunger, period)
+	return int(hunger) * maxVal / int(period)
+}
+
+// How long (in frames) the snake can go without food.
+//
+// The period gets shorter as the game goes, following the curve set in the rules.
+func (s *Score) hungerPeriod() uint16 {
+	steps := s.meals
+	if rules.hungerScale == hungerByTime {
+		steps = ticks / (hungerStepSeconds * fps)
+	}
+	return rules.hungerCurve.period(steps)
+}
+
 // Increase the score.
 //
 // Triggered by [Snake] when eating an apple.
 func (s *Score) inc() {
-	if hungerPeriod > 10 {
-		hungerPeriod -= 1
-	}
-	s.hunger = hungerPeriod
-	s.val += 1
+	s.meals++
 	firefly.AddProgress(s.peer, badgeEat100Apples, 1)
+	s.addPoint()
+}
+
+// Increase the score for eaten pellets.
+//
+// Unlike apples, pellets don't count for the badge
+// and don't make the hunger period shorter.
+func (s *Score) incPellets() {
+	s.addPoint()
+}
+
+// Add one point for food and reset the hunger.
+func (s *Score) addPoint() {
+	s.hunger = s.hungerPeriod()
+	s.val += 1
+	s.best = max(s.best, s.val)
 	s.color = firefly.ColorDarkGreen
 	s.ttl = 60
 }
@@ -101,8 +218,13 @@ func (s *Score) dec() {
 		return
 	}
 	s.iframes = iFrames
+	if me.Eq(s.peer) {
+		playSound(soundBite)
+	}
 	if s.val > 0 {
-		s.val -= (s.val/5 + 1)
+		lost := s.val/5 + 1
+		s.val -= lost
+		s.shrink += lost
 	}
 	s.color = firefly.ColorRed
 	s.ttl = 60
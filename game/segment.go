@@ -2,60 +2,33 @@ package game
 
 import "github.com/firefly-zero/firefly-go/firefly"
 
+// A point of the snake's body.
+//
+// The segment goes from this point to the next point in the [Body].
 type Segment struct {
 	head firefly.Point
-	tail *Segment
 	hurt bool
-}
-
-func (s *Segment) line() Line {
-	ph := s.head
-	pt := s.tail.head
-	ph.X, pt.X = denormalizeX(ph.X, pt.X)
-	ph.Y, pt.Y = denormalizeY(ph.Y, pt.Y)
-	return Line{ph, pt}
-}
 
-// render the snake's segment
-func (s *Segment) render(frame int, state State, me bool) {
-	if s.tail == nil {
-		return
-	}
-	start := s.head
-	end := s.tail.head
-	start.X, end.X = denormalizeX(start.X, end.X)
-	start.Y, end.Y = denormalizeY(start.Y, end.Y)
-	// if this is the last segment (the snake's tail), draw it shorter.
-	if s.tail.tail == nil && state != growing {
-		end.X = start.X + (end.X-start.X)*(period-frame)/period
-		end.Y = start.Y + (end.Y-start.Y)*(period-frame)/period
-	}
-	c := s.color(me)
-	drawSegment(start, end, c)
+	// The sequence number of the segment, one less than the segment behind it.
+	//
+	// It stays the same while the segment exists,
+	// so the pattern painted based on it moves together with the body.
+	seq uint8
 }
 
-func (s *Segment) color(me bool) firefly.Color {
+func (s *Segment) color(p Palette, pattern Pattern) firefly.Color {
 	if s.hurt {
 		return firefly.ColorRed
 	}
-	switch ((s.head.X + s.head.Y) / 12) % 3 {
+	if pattern != patternStripes {
+		return p.base
+	}
+	switch s.seq % 3 {
 	case 0:
-		if me {
-			return firefly.ColorDarkBlue
-		} else {
-			return firefly.ColorDarkGray
-		}
+		return p.dark
 	case 1:
-		if me {
-			return firefly.ColorLightBlue
-		} else {
-			return firefly.ColorLightGray
-		}
+		return p.light
 	default:
-		if me {
-			return firefly.ColorBlue
-		} else {
-			return firefly.ColorGray
-		}
+		return p.base
 	}
 }
@@ -0,0 +1,80 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The file in the app data dir where settings are stored.
+const settingsPath = "settings"
+
+// The highest volume level.
+const maxVolume = 4
+
+var settings Settings
+
+// Local preferences of the player.
+//
+// They affect only what the player sees and hears, never the gameplay,
+// so it's fine for them to be different on different devices.
+type Settings struct {
+	// The volume level, from 0 to [maxVolume].
+	volume uint8
+
+	// If true, no sounds are played.
+	muted bool
+
+	// If true, play a sound when the snake is about to get hungry.
+	hungerCue bool
+
+	// If true, use the lolcat style for all messages instead of the system language.
+	lolcat bool
+
+	// If true, the player asked in the system settings to avoid rapid flashes.
+	//
+	// Not stored in the settings file.
+	reduceFlashing bool
+}
+
+func loadSettings() Settings {
+	s := Settings{
+		volume:         maxVolume,
+		hungerCue:      true,
+		lolcat:         true,
+		reduceFlashing: firefly.GetSettings(me).ReduceFlashing,
+	}
+	raw := firefly.LoadFile(settingsPath, nil)
+	if len(raw) >= 2 {
+		s.volume = min(raw[0], maxVolume)
+		s.muted = raw[1] != 0
+	}
+	if len(raw) >= 3 {
+		s.hungerCue = raw[2] != 0
+	}
+	if len(raw) >= 4 {
+		s.lolcat = raw[3] != 0
+	}
+	return s
+}
+
+func (s Settings) save() {
+	raw := []byte{
+		s.volume,
+		boolByte(s.muted),
+		boolByte(s.hungerCue),
+		boolByte(s.lolcat),
+	}
+	firefly.DumpFile(settingsPath, raw)
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// The gain level for the master audio node.
+func (s Settings) gain() float32 {
+	if s.muted {
+		return 0
+	}
+	return float32(s.volume) / maxVolume
+}
@@ -6,10 +6,28 @@ import (
 )
 
 const (
-	period     = 10
 	snakeWidth = 7
 	segmentLen = 14
 	maxDirDiff = .1
+
+	// The width of the very end of the tail.
+	tailWidth = 2
+	// Over how many last segments the body narrows down to the tail width.
+	taperLen = 4
+	// How many straight pieces each body segment is rendered with.
+	curveSteps = 4
+
+	// How close (in pixels) the center line of the neck should get
+	// to the center line of a body segment to bite it.
+	// Both are snakeWidth wide, so they touch when their centers
+	// are half of the width of each, that is the full width, apart.
+	biteDist = snakeWidth
+
+	// The snake can't be shorter than this many body points.
+	minBodyLen = 2
+
+	// How long (in frames) the death animation plays.
+	dyingFrames = 45
 )
 
 type State uint8
@@ -26,17 +44,41 @@ const (
 	growing State = 2
 )
 
+// Where the snake is in its lifecycle.
+type Life uint8
+
+const (
+	// The snake is in the game.
+	alive Life = iota
+
+	// The snake lost and its body is breaking into pieces.
+	// When the animation is over, the snake is removed.
+	dying
+
+	// The snake is not in the game anymore:
+	// it either died or was attached to another half of the split snake.
+	removed
+)
+
 type Snake struct {
 	peer firefly.Peer
 
 	score *Score
 
-	// The start point of the first full-length segment (the neck).
-	head *Segment
+	// All points of the snake starting from the neck:
+	// the start point of the first full-length segment.
+	body Body
 
 	// The very first point of the snake. Updated based on Dir.
 	mouth firefly.Point
 
+	// The points of the snake as it is seen on the current frame, starting from the mouth.
+	//
+	// Each point of the body slides along the path towards the previous point,
+	// so the whole snake moves smoothly between shifts.
+	// Used both for rendering and collisions.
+	shape []firefly.Point
+
 	eye Eye
 
 	// The snake's movement direction in radians. Updated based on touch pad.
@@ -50,54 +92,96 @@ type Snake struct {
 
 	// If true, render a crown on the snake's head.
 	crown bool
+
+	// The pattern painted on the snake's body.
+	pattern Pattern
+
+	// If true, the snake is controlled by the player.
+	//
+	// After a split, the player controls only one of the halves (the active one)
+	// and the others follow the autopilot.
+	active bool
+
+	// Where the snake is in its lifecycle.
+	life Life
+
+	// How many frames are left of the death animation.
+	deathTimer uint8
 }
 
-func newSnake(i int, peer firefly.Peer) *Snake {
-	shift := 10 + snakeWidth + i*20
+func newSnake(i int, score *Score) *Snake {
+	peer := score.peer
+	shift := hudHeight + 10 + snakeWidth + i*20
 	var youTTL uint8
 	if me.Eq(peer) && isMultiplayer {
 		youTTL = 180
 	}
-	return &Snake{
-		peer:   peer,
-		score:  newScore(peer),
-		youTTL: youTTL,
-		eye:    Eye{},
-		head: &Segment{
-			head: firefly.P(segmentLen*2, shift),
-			tail: &Segment{
-				head: firefly.P(segmentLen, shift),
-				tail: nil,
-			},
-		},
+	s := &Snake{
+		peer:    peer,
+		score:   score,
+		youTTL:  youTTL,
+		active:  true,
+		pattern: Pattern(i % int(nPatterns)),
+		eye:     Eye{},
+		body: newBody(
+			firefly.P(segmentLen*2, shift),
+			firefly.P(segmentLen, shift),
+		),
 	}
+	s.mouth = s.body.neck()
+	s.updateShape(0)
+	return s
 }
 
 // update the position of all snake's segments.
-func (s *Snake) update() {
+func (s *Snake) update(ss *Snakes) {
 	frame = frame % period
 	if s.youTTL > 0 {
 		s.youTTL--
 	}
-	pad, pressed := firefly.ReadPad(s.peer)
-	if pressed {
-		s.setDir(pad)
+	if s.active {
+		pad, pressed := firefly.ReadPad(s.peer)
+		if pressed {
+			s.setDir(pad)
+		}
+	} else {
+		s.autopilot()
 	}
 	if frame == 0 {
 		s.shift()
 	}
 	s.updateMouth(frame)
+	s.updateShape(frame)
 	s.eye.update(s.mouth)
+	if !s.active {
+		return
+	}
+	// All halves of a split snake share the same score,
+	// so only the active one updates it.
 	s.score.update()
 
 	btns := firefly.ReadButtons(s.peer)
-	if btns.S {
-		s.split()
+	justPressed := btns.JustPressed(s.score.btns)
+	s.score.btns = btns
+	if justPressed.S {
+		s.split(ss)
+	} else if justPressed.E {
+		ss.switchActive(s)
 	} else if btns.Any() {
 		s.youTTL = 180
 	}
 }
 
+// Steer the snake when it's not controlled by the player.
+//
+// The autopilot simply turns towards the apple.
+func (s *Snake) autopilot() {
+	// The Y axis of the pad points up while the Y axis of the screen points down.
+	d := wrapDelta(s.mouth, apple.pos)
+	pad := firefly.Pad{X: d.X, Y: -d.Y}
+	s.setDir(pad)
+}
+
 // Set Dir value based on the pad input.
 func (s *Snake) setDir(pad firefly.Pad) {
 	dirDiff := pad.Azimuth().Radians() - s.dir
@@ -135,35 +219,45 @@ func (s *Snake) setDir(pad firefly.Pad) {
 func (s *Snake) shift() {
 	shiftX := tinymath.Cos(s.dir) * segmentLen
 	shiftY := tinymath.Sin(s.dir) * segmentLen
+	neck := s.body.neck()
 	head := firefly.Point{
-		X: normalizeX(s.head.head.X + int(shiftX)),
-		Y: normalizeY(s.head.head.Y - int(shiftY)),
+		X: normalizeX(neck.X + int(shiftX)),
+		Y: normalizeY(neck.Y - int(shiftY)),
 	}
 
-	if s.state == growing {
-		s.head = &Segment{
-			head: head,
-			tail: s.head,
-		}
+	// Lost points first cancel the growth that hasn't happened yet.
+	if s.score.shrink > 0 && s.state != moving {
+		s.score.shrink--
 		s.state = moving
-		return
 	}
-	if s.state == eating {
+	switch s.state {
+	case growing:
+		s.body.pushFront(head)
+		s.state = moving
+	case eating:
 		s.state = growing
+		s.body.shift(head)
+	default:
+		s.body.shift(head)
 	}
+	if s.score.shrink > 0 {
+		s.shrinkTail()
+	}
+}
 
-	segment := s.head
-	for segment != nil {
-		oldHead := segment.head
-		segment.head = head
-		head = oldHead
-		segment = segment.tail
+// Remove the last segment of the body because the snake lost points.
+//
+// If the snake is already as short as it can be, the segment is just forgiven.
+func (s *Snake) shrinkTail() {
+	s.score.shrink--
+	if s.body.len > minBodyLen {
+		s.body.truncate(s.body.len - 1)
 	}
 }
 
 // Update snake's mouth position based on the current frame and direction.
 func (s *Snake) updateMouth(frame int) {
-	neck := s.head.head
+	neck := s.body.neck()
 	headLen := float32(segmentLen) * float32(frame) / float32(period)
 	shiftX := tinymath.Cos(s.dir) * headLen
 	shiftY := tinymath.Sin(s.dir) * headLen
@@ -172,14 +266,37 @@ func (s *Snake) updateMouth(frame int) {
 	s.mouth = firefly.P(x, y)
 }
 
+// Update the snake's shape based on the current frame.
+//
+// Every point moves towards the previous one the same way as the mouth
+// moves from the neck. If the snake is growing, the tail stays in place.
+func (s *Snake) updateShape(frame int) {
+	s.shape = append(s.shape[:0], s.mouth)
+	prev := s.body.neck()
+	for i := 1; i < s.body.len; i++ {
+		p := s.body.at(i).head
+		s.shape = append(s.shape, lerpPoint(p, prev, frame, period))
+		prev = p
+	}
+	if s.state == growing {
+		s.shape = append(s.shape, prev)
+	}
+}
+
+// Get the line between the given point of the shape and the next one.
+func (s *Snake) shapeLine(i int) Line {
+	return wrappedLine(s.shape[i], s.shape[i+1])
+}
+
 // Check if the snake can eat the apple.
 //
 // If it can, start growing the snake and move the apple.
 func (s *Snake) tryEat() {
 	const minDist = (appleRadius+snakeWidth)/2 + 3
 	const minDist2 = minDist * minDist
-	dx := float32(apple.pos.X - s.mouth.X)
-	dy := float32(apple.pos.Y - s.mouth.Y)
+	d := wrapDelta(s.mouth, apple.pos)
+	dx := float32(d.X)
+	dy := float32(d.Y)
 	dist2 := dx*dx + dy*dy
 	if dist2 > minDist2 {
 		return
@@ -187,23 +304,55 @@ func (s *Snake) tryEat() {
 	s.state = eating
 	apple.move()
 	s.score.inc()
+	playSound(soundEat)
+	countApple()
+	run.eat()
+}
+
+// Eat all pellets near the mouth. Every few pellets give a point.
+func (s *Snake) eatPellets() {
+	eaten := pellets.eat(s.mouth)
+	if eaten == 0 {
+		return
+	}
+	playSound(soundEat)
+	for range eaten {
+		s.score.pellets++
+		if s.score.pellets == pelletsPerPoint {
+			s.score.pellets = 0
+			s.state = eating
+			s.score.incPellets()
+		}
+	}
+}
+
+// Decrease the score by the number of removed segments if the score is the length.
+func (s *Snake) loseLength(n int) {
+	if !rules.lengthScore {
+		return
+	}
+	s.score.val = max(0, s.score.val-int16(n))
+	s.score.color = firefly.ColorRed
+	s.score.ttl = 60
+}
+
+// Leave a pellet in the middle of each body segment starting from the given one.
+func (s *Snake) dropPellets(from int) {
+	c := palette(s.peer).base
+	for i := from; i < len(s.shape)-1; i++ {
+		line := s.shapeLine(i)
+		pellets.add(lerpPoint(line.h, line.t, 1, 2), c)
+	}
 }
 
 // Check if the given apple position is within the snake's body.
 func (s *Snake) appleCollides(p firefly.Point) bool {
-	segment := s.head.tail
-	for segment != nil {
-		if segment.tail != nil {
-			ph := segment.head
-			pt := segment.tail.head
-			ph.X, pt.X = denormalizeX(ph.X, pt.X)
-			ph.Y, pt.Y = denormalizeY(ph.Y, pt.Y)
-			bbox := newBBox(ph, pt, snakeWidth/2)
-			if bbox.contains(p) {
-				return true
-			}
+	for i := 1; i < len(s.shape)-1; i++ {
+		line := lineNear(s.shapeLine(i), p)
+		bbox := newBBox(line.h, line.t, snakeWidth/2)
+		if bbox.contains(p) {
+			return true
 		}
-		segment = segment.tail
 	}
 	return false
 }
@@ -212,95 +361,289 @@ func (s *Snake) appleCollides(p firefly.Point) bool {
 //
 // Bites is detected based on if the first segment of this snake
 // intersects any of the segments of the other snake.
-func (s *Snake) bites(me bool, other *Snake) bool {
-	neck := &Segment{head: s.mouth, tail: s.head}
-	neckLine := neck.line()
-	segment := other.head.tail
-	if segment != nil && me {
-		segment = segment.tail
-	}
-	for segment != nil {
-		if segment.tail != nil {
-			segment.hurt = false
-			if intersect(segment.line(), neckLine) {
-				segment.hurt = true
-				return true
-			}
+func (s *Snake) bites(me bool, other *Snake) (int, bool) {
+	neckLine := s.shapeLine(0)
+	// The own segment right behind the neck always touches the neck.
+	first := 1
+	if me {
+		first = 2
+	}
+	last := len(other.shape) - 2
+	// Touching the tail of another half of the same snake is not a bite,
+	// the halves merge instead.
+	if !me && s.score == other.score {
+		last--
+	}
+	for i := first; i <= last; i++ {
+		segment := other.segment(i)
+		segment.hurt = false
+		if thickIntersect(lineNear(other.shapeLine(i), neckLine.h), neckLine, biteDist) {
+			segment.hurt = true
+			return i, true
 		}
-		segment = segment.tail
 	}
-	return false
+	return 0, false
+}
+
+// Cut off the body starting from the given segment.
+//
+// The cut off part turns into pellets.
+// The neck segment is never cut off, even if it's bitten.
+func (s *Snake) cut(i int) {
+	// Keep the points up to the start of the bitten segment.
+	keep := max(i+1, minBodyLen)
+	if keep >= s.body.len {
+		return
+	}
+	s.dropPellets(keep - 1)
+	s.loseLength(s.body.len - keep)
+	s.body.truncate(keep)
+	s.updateShape(frame)
+}
+
+// Check if the mouths of this and the other snake hit each other.
+func (s *Snake) headOn(other *Snake) bool {
+	neck := s.shapeLine(0)
+	otherNeck := lineNear(other.shapeLine(0), neck.h)
+	return thickIntersect(neck, otherNeck, biteDist)
+}
+
+// Turn the snake away after a head-on collision.
+//
+// Snakes hitting each other head-on both turn left (counter-clockwise),
+// so they go in opposite directions.
+func (s *Snake) bounce() {
+	// Already bounced recently, don't turn back into the other snake.
+	if s.score.iframes > 0 {
+		return
+	}
+	s.score.iframes = iFrames
+	s.dir += tinymath.Pi / 2
+	if s.dir > tinymath.Tau {
+		s.dir -= tinymath.Tau
+	}
 }
 
 // Split the snake into two in the middle.
 //
 // It also removes one segment from the middle
 // to make it a bit easier to avoid snakes collision.
-func (s *Snake) split() {
-	nSegments := 0
-	segment := s.head
-	for segment != nil {
-		nSegments += 1
-		segment = segment.tail
-	}
+func (s *Snake) split(ss *Snakes) {
+	nSegments := s.body.len
 	if nSegments < 6 {
 		return
 	}
+	cut := nSegments/2 - 1
+	newBody := s.body.slice(cut+1, nSegments)
+	s.body.truncate(cut)
+	s.loseLength(1)
 
-	segment = s.head
-	for range nSegments/2 - 2 {
-		segment = segment.tail
-	}
-
-	newHead := segment.tail.tail
-	segment.tail = nil
-
+	playSound(soundSplit)
+	s.updateShape(frame)
 	newSnake := &Snake{
 		peer:  s.peer,  // Both snakes are controlled by the same player.
 		score: s.score, // Both snakes share the same score.
-		head:  newHead,
-		mouth: newHead.head,
+		body:  newBody,
+		mouth: newBody.neck(),
 		dir:   s.dir,
+		// The player keeps controlling the front half.
+		active:  false,
+		pattern: s.pattern,
+	}
+	newSnake.updateShape(0)
+	ss.spawn(newSnake)
+}
+
+// Check if the snake is in the game and hasn't lost on the current update.
+func (s *Snake) inGame() bool {
+	return s.life == alive && s.score.death == deathNone
+}
+
+// Check if the mouth of the snake touches the last segment of the other snake.
+func (s *Snake) touchesTail(other *Snake) bool {
+	tail := other.shapeLine(len(other.shape) - 2)
+	tail = lineNear(tail, s.mouth)
+	return dist2ToLine(s.mouth, tail) <= snakeWidth*snakeWidth
+}
+
+// Attach the body of the other snake to the end of this snake.
+//
+// The other snake should be discarded after that.
+func (s *Snake) attach(other *Snake) {
+	s.body.extend(&other.body)
+	s.updateShape(frame)
+	// Don't lose the growth if the attached snake just ate an apple.
+	if s.state == moving {
+		s.state = other.state
 	}
-	snakes.items = append(snakes.items, newSnake)
+	s.active = s.active || other.active
+	other.active = false
+	playSound(soundMerge)
 }
 
 // render all segments and the head of the snake
 func (s *Snake) render() {
-	frame = frame % period
-	segment := s.head
-	for segment != nil {
-		segment.render(frame, s.state, me.Eq(s.peer))
-		segment = segment.tail
+	if s.life == dying {
+		s.renderDying()
+		return
+	}
+	flash := s.flashing()
+	s.renderBody(flash)
+	if !s.active {
+		s.renderLink()
 	}
-	s.renderNeck()
 	if s.crown {
-		s.renderCrown()
+		drawToroidalAround(s.mouth, snakeWidth+2, func(shift firefly.Point) {
+			renderCrownAt(s.mouth.Add(shift))
+		})
+	}
+	isMe := me.Eq(s.peer)
+	starving := s.score.starving()
+	drawToroidalAround(s.mouth, snakeWidth, func(shift firefly.Point) {
+		s.eye.render(s.mouth.Add(shift), isMe, starving)
+	})
+	// We reset it only after rendering to make sure to render it
+	// for at least one frame.
+	s.eye.hurt = false
+	if s.score.val != 0 {
+		drawToroidalAround(s.mouth, snakeWidth+2, s.renderHunger)
 	}
-	s.eye.render(s.mouth, me.Eq(s.peer))
 	if s.youTTL != 0 {
 		s.renderYou()
 	}
-	if s.score.ttl != 0 {
-		s.renderScore()
+}
+
+// Render the body broken into pieces that shrink and fade away.
+func (s *Snake) renderDying() {
+	pal := palette(s.peer)
+	// How far the animation went, from 0 to dyingFrames.
+	progress := dyingFrames - int(s.deathTimer)
+	c := pal.base
+	if progress > dyingFrames*2/3 {
+		c = firefly.ColorLightGray
+	} else if progress > dyingFrames/3 {
+		c = pal.light
+	}
+	width := max(1, snakeWidth-snakeWidth*progress/dyingFrames)
+	for i := range len(s.shape) - 1 {
+		line := s.shapeLine(i)
+		// Each piece shrinks towards its middle, opening gaps between pieces.
+		h := lerpPoint(line.h, line.t, progress, dyingFrames*3)
+		t := lerpPoint(line.t, line.h, progress, dyingFrames*3)
+		drawSegment(h, nearest(t, h), c, width)
+	}
+}
+
+// Render all segments of the snake as a smooth curve narrowing down to the tail.
+func (s *Snake) renderBody(flash bool) {
+	pal := palette(s.peer)
+	// Render from the tail so that the segments closer to the head are on top.
+	for i := len(s.shape) - 2; i >= 0; i-- {
+		segment := s.segment(i)
+		c := segment.color(pal, s.pattern)
+		if i == 0 {
+			// The neck is always of the same color as the head.
+			c = pal.base
+		}
+		if flash && (i == 0 || !segment.hurt) {
+			c = firefly.ColorOrange
+		}
+		s.renderCurve(i, s.widthAt(i), c, pal.dark)
+	}
+}
+
+// Render the segment starting at the given point of the shape as a curve.
+//
+// The curve goes through the shape points and its bend is defined by the neighbor points.
+func (s *Snake) renderCurve(i, width int, c, scaleColor firefly.Color) {
+	line := s.shapeLine(i)
+	p0 := line.h
+	if i > 0 {
+		p0 = nearest(s.shape[i-1], line.h)
+	}
+	p3 := line.t
+	if i+2 < len(s.shape) {
+		p3 = nearest(s.shape[i+2], line.t)
+	}
+	prev := line.h
+	for step := 1; step <= curveSteps; step++ {
+		p := catmullRom(p0, line.h, line.t, p3, float32(step)/curveSteps)
+		drawSegment(prev, p, c, width)
+		prev = p
+	}
+	if s.pattern == patternScales && i != 0 {
+		mid := catmullRom(p0, line.h, line.t, p3, .5)
+		drawSegment(mid, mid, scaleColor, width/2+1)
+	}
+}
+
+// Get the width of the body at the given segment.
+//
+// The last few segments are narrower to make the tail pointy.
+func (s *Snake) widthAt(i int) int {
+	left := len(s.shape) - 1 - i
+	if left >= taperLen {
+		return snakeWidth
+	}
+	return tailWidth + (snakeWidth-tailWidth)*left/taperLen
+}
+
+// Get the body segment that is rendered between the given point of the shape and the next one.
+func (s *Snake) segment(i int) *Segment {
+	return s.body.at(min(i, s.body.len-1))
+}
+
+// Check if the snake's body should be highlighted on this frame
+// to warn the player that the snake is starving.
+func (s *Snake) flashing() bool {
+	if !s.score.starving() || settings.reduceFlashing {
+		return false
+	}
+	return (s.score.hunger/8)%2 == 0
+}
+
+// Render a dotted line from the head of an inactive half
+// to the head of the active half of the same snake.
+func (s *Snake) renderLink() {
+	active := snakes.activeSibling(s)
+	if active == nil {
+		return
+	}
+	// Go to the active half the shortest way, even if it is across the screen edge.
+	d := wrapDelta(s.mouth, active.mouth)
+	dx, dy := d.X, d.Y
+	c := palette(s.peer).light
+	const step = 8
+	dist := int(tinymath.Hypot(float32(dx), float32(dy)))
+	for d := step; d < dist; d += step {
+		p := firefly.P(
+			normalizeX(s.mouth.X+dx*d/dist),
+			normalizeY(s.mouth.Y+dy*d/dist),
+		)
+		firefly.DrawCircle(p.Sub(firefly.P(1, 1)), 3, firefly.Solid(c))
 	}
 }
 
-// Draw the zero segment of the snake: it's neck.
-func (s *Snake) renderNeck() {
-	neck := s.head.head
-	mouth := s.mouth
-	neck.X, mouth.X = denormalizeX(neck.X, mouth.X)
-	neck.Y, mouth.Y = denormalizeY(neck.Y, mouth.Y)
-	c := firefly.ColorBlue
-	if !me.Eq(s.peer) {
-		c = firefly.ColorGray
+// Render a bar under the snake's head showing how long it can go without food.
+//
+// The bar is shifted by the given offset.
+func (s *Snake) renderHunger(shift firefly.Point) {
+	const barWidth = snakeWidth * 2
+	mouth := s.mouth.Add(shift)
+	left := firefly.P(mouth.X-barWidth/2, mouth.Y+snakeWidth/2+3)
+	firefly.DrawRect(left, firefly.S(barWidth, 2), firefly.Solid(firefly.ColorLightGray))
+	c := firefly.ColorGreen
+	if s.score.starving() {
+		c = firefly.ColorRed
+	}
+	filled := s.score.hungerLeft(barWidth)
+	if filled > 0 {
+		firefly.DrawRect(left, firefly.S(filled, 2), firefly.Solid(c))
 	}
-	drawSegment(neck, mouth, c)
 }
 
-func (s *Snake) renderCrown() {
-	mouth := s.mouth
+// Render a crown on top of the given head position.
+func renderCrownAt(mouth firefly.Point) {
 	left := mouth.Add(firefly.P(-snakeWidth/2, -snakeWidth/2))
 	right := mouth.Add(firefly.P(snakeWidth/2, -snakeWidth/2))
 	topY := mouth.Y - 8
@@ -326,60 +669,36 @@ func (s *Snake) renderCrown() {
 
 // Render a "you" message above the snake's head.
 func (s *Snake) renderYou() {
-	x := s.mouth.X - font.CharWidth()*3/2
+	text := tr(msgYou)
+	x := s.mouth.X - font.LineWidth(text)/2
 	y := s.mouth.Y - 6
-	font.Draw("you", firefly.P(x, y), firefly.ColorRed)
-}
-
-func (s *Snake) renderScore() {
-	font.Draw(
-		formatInt(s.score.val),
-		firefly.P(
-			s.mouth.X-font.CharWidth(),
-			s.mouth.Y+snakeWidth+font.CharHeight(),
-		),
-		s.score.color,
-	)
+	drawToroidalText(text, firefly.P(x, y), firefly.ColorRed)
 }
 
 // Render the segment and ghost segments if the snake wraps around the screen edges.
-func drawSegment(start, end firefly.Point, c firefly.Color) {
-	drawSegmentExactlyAt(start, end, c)
-	drawSegmentExactlyAt(
-		firefly.P(start.X-firefly.Width, start.Y),
-		firefly.P(end.X-firefly.Width, end.Y),
-		c,
-	)
-	drawSegmentExactlyAt(
-		firefly.P(start.X, start.Y-firefly.Height),
-		firefly.P(end.X, end.Y-firefly.Height),
-		c,
-	)
-	drawSegmentExactlyAt(
-		firefly.P(start.X-firefly.Width, start.Y-firefly.Height),
-		firefly.P(end.X-firefly.Width, end.Y-firefly.Height),
-		c,
+func drawSegment(start, end firefly.Point, c firefly.Color, width int) {
+	r := firefly.P(width, width)
+	drawToroidal(
+		start.ComponentMin(end).Sub(r),
+		start.ComponentMax(end).Add(r),
+		func(shift firefly.Point) {
+			drawSegmentExactlyAt(start.Add(shift), end.Add(shift), c, width)
+		},
 	)
 }
 
 // Render the segment.
-func drawSegmentExactlyAt(start, end firefly.Point, c firefly.Color) {
-	if start.X < 0 && end.X < 0 {
-		return
-	}
-	if start.Y < 0 && end.Y < 0 {
-		return
-	}
+func drawSegmentExactlyAt(start, end firefly.Point, c firefly.Color, width int) {
 	firefly.DrawLine(
 		start, end,
-		firefly.L(c, snakeWidth),
+		firefly.L(c, width),
 	)
 	firefly.DrawCircle(
 		firefly.Point{
-			X: end.X - snakeWidth/2,
-			Y: end.Y - snakeWidth/2,
+			X: end.X - width/2,
+			Y: end.Y - width/2,
 		},
-		snakeWidth,
+		width,
 		firefly.Solid(c),
 	)
 }
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"slices"
+
 	"github.com/firefly-zero/firefly-go/firefly"
 )
 
@@ -9,35 +11,112 @@ var isMultiplayer bool
 
 type Snakes struct {
 	items []*Snake
+
+	// Bites detected on the current frame.
+	bites []Bite
+
+	// Snakes created on the current frame.
+	//
+	// They are added to the list of snakes at the end of the update,
+	// so that the list doesn't change while it's being iterated.
+	spawned []*Snake
+
+	// Scores of players who lost on the current frame.
+	lost []*Score
+
+	// Players waiting to get back into the game.
+	respawns []Respawn
+}
+
+// A player waiting to get back into the game after losing.
+type Respawn struct {
+	score *Score
+
+	// How many frames are left before the player respawns.
+	timer int
+}
+
+const (
+	// How long (in frames) a player who lost waits before respawning.
+	respawnDelay = 180
+
+	// How long (in frames) the match lasts when players respawn.
+	respawnMatchTime = 180 * fps
+)
+
+// One snake biting another snake or itself.
+type Bite struct {
+	biter  *Snake
+	victim *Snake
+
+	// If true, the mouths of the snakes hit each other
+	// and it's not clear who bit whom.
+	headOn bool
+
+	// The index of the bitten segment of the victim.
+	segment int
 }
 
 func newSnakes() *Snakes {
 	peers := firefly.GetPeers().Slice()
 
-	// Set the global hunger period based on the number of players.
+	// Set the initial hunger period based on the number of players.
 	// The more people play, the longer it takes for one snake
 	// to get an apple (because of competition).
 	hungerPeriodSeconds := 4 + len(peers)
-	hungerPeriod = uint16(hungerPeriodSeconds) * 60
+	hungerBase = uint16(hungerPeriodSeconds) * fps
 
 	isMultiplayer = len(peers) != 1
 	snakes := make([]*Snake, len(peers))
 	for i, peer := range peers {
-		snakes[i] = newSnake(i, peer)
+		snakes[i] = newSnake(i, newScore(peer))
 	}
-	return &Snakes{snakes}
+	return &Snakes{items: snakes}
 }
 
+// Update all snakes.
+//
+// The update goes in stages. First, all snakes move and eat.
+// Then bites are detected and resolved. Snakes that lost are only marked as dying,
+// and the list of snakes is changed only in the final cleanup stage.
 func (ss *Snakes) update() {
 	if ss == nil {
 		return
 	}
+	ss.move()
+	ss.merge()
+	ss.detectBites()
+	ss.resolveBites()
+	ss.hitWalls()
+	ss.respawn()
+	ss.cleanup()
+	ss.checkTime()
+}
+
+// Move all snakes, let them eat, and find who wears the crown.
+func (ss *Snakes) move() {
+	// The speed changes only between shifts, so that snakes don't jump.
+	if frame%period == 0 {
+		frame = 0
+		period = levelPeriod()
+	}
 	var best *Snake
 	var bestScore int16 = 0
 	for _, snake := range ss.items {
 		snake.crown = false
-		snake.update()
-		snake.tryEat()
+		if snake.life == dying {
+			snake.deathTimer--
+			continue
+		}
+		snake.update(ss)
+		if snake.inGame() {
+			snake.tryEat()
+			snake.eatPellets()
+		}
+		if best != nil && snake.score == best.score {
+			// Halves of a split snake share the same score.
+			continue
+		}
 		score := snake.score.val
 		if score == bestScore {
 			// Nobody's the best if there is a tie.
@@ -48,77 +127,330 @@ func (ss *Snakes) update() {
 		}
 	}
 	// In multiplayer, render a crown on the best snake.
-	if len(ss.items) > 1 && best != nil {
+	if isMultiplayer && best != nil {
 		best.crown = true
 	}
+}
 
-	for i, s1 := range snakes.items {
-		for j, s2 := range snakes.items {
-			sameSnake := i == j
-			if !s1.bites(sameSnake, s2) {
+// Find all bites that happen on this frame.
+//
+// Bites are first collected for all snakes and only then applied,
+// so that the outcome doesn't depend on the order of snakes.
+func (ss *Snakes) detectBites() {
+	ss.bites = ss.bites[:0]
+	for i, s1 := range ss.items {
+		if !s1.inGame() {
+			continue
+		}
+		for j, s2 := range ss.items {
+			if !s2.inGame() {
 				continue
 			}
-			if sameSnake {
-				firefly.AddProgress(s1.peer, badgeBiteSelf, 1)
-			} else {
-				firefly.AddProgress(s1.peer, badgeBiteOther, 1)
+			if i != j && s1.score != s2.score && s1.headOn(s2) {
+				// Record a head-on collision only once for each pair.
+				if i < j {
+					ss.bites = append(ss.bites, Bite{biter: s1, victim: s2, headOn: true})
+				}
+				continue
 			}
-			s1.eye.hurt = true
-			s1.score.dec()
+			if segment, ok := s1.bites(i == j, s2); ok {
+				ss.bites = append(ss.bites, Bite{biter: s1, victim: s2, segment: segment})
+			}
+		}
+	}
+}
 
-			// If the snake reached zero score, handle game over.
-			if s1.score.val != 0 {
+// Apply all bites detected on this frame.
+//
+// The biter loses points and the victim loses the part of the body
+// behind the bitten segment.
+func (ss *Snakes) resolveBites() {
+	for _, b := range ss.bites {
+		if !b.headOn {
+			cause := deathBitOther
+			if b.biter == b.victim {
+				cause = deathBitSelf
+			}
+			ss.hurt(b.biter, cause)
+			b.victim.cut(b.segment)
+			continue
+		}
+		s1, s2 := b.biter, b.victim
+		switch rules.headOn {
+		case headOnBothHurt:
+			ss.hurt(s1, deathBitOther)
+			ss.hurt(s2, deathBitOther)
+		case headOnBiggerWins:
+			// Compare the scores before any of them is decreased.
+			v1, v2 := s1.score.val, s2.score.val
+			if v1 <= v2 {
+				ss.hurt(s1, deathBitOther)
+			}
+			if v2 <= v1 {
+				ss.hurt(s2, deathBitOther)
+			}
+		case headOnBounce:
+			s1.bounce()
+			s2.bounce()
+		}
+	}
+}
+
+// Snakes that hit a wall of the campaign stage lose right away.
+//
+// Unlike bites, walls ignore the invincibility frames.
+// Otherwise, a snake that was just bitten could pass through a wall.
+func (ss *Snakes) hitWalls() {
+	for _, s := range ss.items {
+		if s.inGame() && run.hitsWall(s) {
+			s.eye.hurt = true
+			s.score.death = deathHitWall
+		}
+	}
+}
+
+// Decrease the score of the snake that bit itself or another snake.
+//
+// If the score drops to zero, the player loses for the given reason.
+func (ss *Snakes) hurt(s *Snake, cause Death) {
+	// The snake might have already lost from another bite on this frame.
+	// Right after a bite or respawn, the snake is invincible.
+	if !s.inGame() || s.score.iframes > 0 {
+		return
+	}
+	switch cause {
+	case deathBitSelf:
+		firefly.AddProgress(s.peer, badgeBiteSelf, 1)
+	case deathBitOther:
+		firefly.AddProgress(s.peer, badgeBiteOther, 1)
+	}
+	s.eye.hurt = true
+	s.score.dec()
+
+	if s.score.val != 0 {
+		return
+	}
+	// The snake is removed from the game at the cleanup stage.
+	s.score.death = cause
+}
+
+// Join halves of a split snake when the mouth of one touches the tail of another.
+//
+// The half whose tail was touched stays in front,
+// the other half is attached to the end of it
+// and is removed from the list of snakes at the cleanup stage.
+func (ss *Snakes) merge() {
+	for _, s1 := range ss.items {
+		for _, s2 := range ss.items {
+			if s1 == s2 || s1.score != s2.score || !s1.inGame() || !s2.inGame() {
 				continue
 			}
-			updateLeaderBoard()
-			snakes.deleteSnake(s1)
-			gameOver := snakes.gameOver()
-			if sameSnake {
-				if me.Eq(s1.peer) {
-					setTitle("u bit urself :(", gameOver)
-				} else if gameOver {
-					setTitle("other snek bit itself, u win", gameOver)
-				}
-			} else {
-				if me.Eq(s1.peer) {
-					setTitle("u lose :(", gameOver)
-				} else if gameOver {
-					setTitle("u win", gameOver)
-				}
+			if !s1.touchesTail(s2) {
+				continue
 			}
+			s2.attach(s1)
+			s1.life = removed
+		}
+	}
+}
+
+// Bring back into the game players who waited long enough after losing.
+func (ss *Snakes) respawn() {
+	waiting := ss.respawns[:0]
+	for _, r := range ss.respawns {
+		r.timer--
+		if r.timer > 0 {
+			waiting = append(waiting, r)
+			continue
+		}
+		r.score.reset()
+		ss.spawn(newSnake(peerIndex(r.score.peer), r.score))
+		// The local player is back in the game, drop the "you died" title.
+		if me.Eq(r.score.peer) && title != nil && !title.blocking {
+			title = nil
 		}
 	}
+	ss.respawns = waiting
 }
 
-func (ss *Snakes) deleteSnake(tar *Snake) {
-	newItems := make([]*Snake, 0, len(ss.items)-1)
+// Start the death animation for snakes of players who lost on this frame,
+// remove from the game snakes for which the animation is over,
+// and add to the game snakes that were created on this frame.
+func (ss *Snakes) cleanup() {
+	ss.lost = ss.lost[:0]
 	for _, s := range ss.items {
-		if s != tar {
-			newItems = append(newItems, s)
+		switch {
+		case s.life == alive && s.score.death != deathNone:
+			s.life = dying
+			s.deathTimer = dyingFrames
+		case s.life == dying && s.deathTimer == 0:
+			s.life = removed
+			s.dropPellets(0)
+			// Halves of a split snake die together, remember each player only once.
+			if !slices.Contains(ss.lost, s.score) {
+				ss.lost = append(ss.lost, s.score)
+			}
 		}
 	}
-	ss.items = newItems
+	items := ss.items[:0]
+	for _, s := range ss.items {
+		if s.life != removed {
+			items = append(items, s)
+		}
+	}
+	clear(ss.items[len(items):])
+	ss.items = append(items, ss.spawned...)
+	clear(ss.spawned)
+	ss.spawned = ss.spawned[:0]
+
+	if len(ss.lost) == 0 {
+		return
+	}
+	if rules.respawn {
+		for _, score := range ss.lost {
+			ss.respawns = append(ss.respawns, Respawn{score: score, timer: respawnDelay})
+		}
+	}
+	// The title of the local player goes first,
+	// so that "you died" isn't replaced by the news about someone else.
+	gameOver := ss.gameOver()
+	for _, score := range ss.lost {
+		if me.Eq(score.peer) {
+			setTitle(score.death.msg(true), gameOver)
+		}
+	}
+	if !gameOver {
+		return
+	}
+	for _, score := range ss.lost {
+		if !me.Eq(score.peer) {
+			setTitle(score.death.msg(false), gameOver)
+		}
+	}
+}
+
+// End the match when the time is up.
+//
+// With respawn on, players never run out of lives,
+// so the match lasts for a fixed time instead.
+// The player with the highest best score wins.
+func (ss *Snakes) checkTime() {
+	if !rules.respawn || run != nil || ticks < respawnMatchTime {
+		return
+	}
+	// The game is already over.
+	if title != nil && title.blocking {
+		return
+	}
+	// The result of the match replaces the "you died" title
+	// of the player who is still waiting to respawn.
+	title = nil
+	setTitle(ss.timeUpMsg(), true)
+}
+
+// The title to show to the local player when the time is up.
+func (ss *Snakes) timeUpMsg() Msg {
+	if !isMultiplayer {
+		return msgTimeUp
+	}
+	mine := hud.myScore()
+	msg := msgWin
+	for _, score := range hud.scores {
+		if score == mine {
+			continue
+		}
+		if score.best > mine.best {
+			return msgLose
+		}
+		if score.best == mine.best {
+			msg = msgTimeUp
+		}
+	}
+	return msg
+}
+
+// Add the snake into the game at the end of the current update.
+func (ss *Snakes) spawn(s *Snake) {
+	ss.spawned = append(ss.spawned, s)
+}
+
+// Give the player control over the next half of the split snake.
+func (ss *Snakes) switchActive(current *Snake) {
+	n := len(ss.items)
+	start := 0
+	for i, s := range ss.items {
+		if s == current {
+			start = i
+		}
+	}
+	for i := 1; i < n; i++ {
+		s := ss.items[(start+i)%n]
+		if s.score == current.score {
+			current.active = false
+			s.active = true
+			return
+		}
+	}
+}
+
+// Check if one of the snakes before the given index shares the score with it.
+//
+// Used to count the shared score of a split snake only once.
+func (ss *Snakes) hasSiblingBefore(i int) bool {
+	for _, s := range ss.items[:i] {
+		if s.score == ss.items[i].score {
+			return true
+		}
+	}
+	return false
 }
 
-func (ss *Snakes) deletePeer(peer firefly.Peer) {
-	newItems := make([]*Snake, 0, len(ss.items)-1)
+// Count snakes in the game that share the given score.
+func (ss *Snakes) halves(score *Score) int {
+	n := 0
 	for _, s := range ss.items {
-		if s.peer != peer {
-			newItems = append(newItems, s)
+		if s.score == score && s.inGame() {
+			n++
+		}
+	}
+	return n
+}
+
+// Find the half of the split snake that is controlled by the player.
+func (ss *Snakes) activeSibling(s *Snake) *Snake {
+	for _, other := range ss.items {
+		if other.score == s.score && other.active {
+			return other
 		}
 	}
-	ss.items = newItems
+	return nil
 }
 
 // Check if the game over screen should be shown.
 //
 // In single-player, we end the game when the only snake dies.
-// In multiplayer, we end the game when only one snake is left.
+// In multiplayer, we end the game when only one player is left.
+// Players waiting to respawn are still in the game,
+// so with respawn on the match ends only when the time is up (see [Snakes.checkTime]).
 func (ss *Snakes) gameOver() bool {
-	if len(ss.items) == 0 {
+	n := ss.players()
+	if n == 0 {
 		return true
 	}
-	return isMultiplayer && len(ss.items) == 1
+	return isMultiplayer && n == 1
+}
+
+// Count players that are still in the game or waiting to respawn.
+//
+// Halves of a split snake are counted as one player.
+func (ss *Snakes) players() int {
+	n := len(ss.respawns)
+	for i, s := range ss.items {
+		if s.life == alive && !ss.hasSiblingBefore(i) {
+			n++
+		}
+	}
+	return n
 }
 
 func (ss *Snakes) render() {
@@ -130,6 +462,16 @@ func (ss *Snakes) render() {
 	}
 }
 
+// Check if the snake owning the given score wears the crown.
+func (ss *Snakes) hasCrown(score *Score) bool {
+	for _, s := range ss.items {
+		if s.score == score && s.crown {
+			return true
+		}
+	}
+	return false
+}
+
 // Check if an apple placed at the given point would collide with any snake.
 //
 // Used to pick a spot for a new apple position.
@@ -138,9 +480,21 @@ func (ss *Snakes) appleInside(pos firefly.Point) bool {
 		return false
 	}
 	for _, s := range ss.items {
-		if s.appleCollides(pos) {
+		if s.life == alive && s.appleCollides(pos) {
 			return true
 		}
 	}
 	return false
 }
+
+// Get the index of the peer in the list of all peers.
+//
+// Used to place the snake of the player on its own row.
+func peerIndex(peer firefly.Peer) int {
+	for i, p := range firefly.GetPeers().Slice() {
+		if p.Eq(peer) {
+			return i
+		}
+	}
+	return 0
+}
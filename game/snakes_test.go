@@ -0,0 +1,374 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// Make a snake with the mouth at the first point and the body going through the rest.
+//
+// The snake has zero score and no invincibility, so any bite kills it.
+func testSnake(points ...firefly.Point) *Snake {
+	s := &Snake{
+		score: &Score{},
+		body:  newBody(points...),
+		mouth: points[0],
+	}
+	s.updateShape(0)
+	return s
+}
+
+// Make a horizontal snake going left from the mouth.
+func testSnakeH(x, y, n int) *Snake {
+	points := make([]firefly.Point, n)
+	for i := range points {
+		points[i] = firefly.P(x-i*segmentLen, y)
+	}
+	return testSnake(points...)
+}
+
+// Make a vertical snake going up from the mouth.
+func testSnakeV(x, y, n int) *Snake {
+	points := make([]firefly.Point, n)
+	for i := range points {
+		points[i] = firefly.P(x, y-i*segmentLen)
+	}
+	return testSnake(points...)
+}
+
+// Set up globals for a collision test and restore them after it.
+func setupCollisions(t *testing.T, headOn HeadOnRule) {
+	oldRules, oldMultiplayer := rules, isMultiplayer
+	frame = 0
+	rules = Rules{headOn: headOn}
+	isMultiplayer = true
+	pellets = Pellets{}
+	title = nil
+	t.Cleanup(func() {
+		rules, isMultiplayer = oldRules, oldMultiplayer
+		pellets = Pellets{}
+		title = nil
+	})
+}
+
+// Run the collision stages of the update.
+func (ss *Snakes) collide() {
+	ss.merge()
+	ss.detectBites()
+	ss.resolveBites()
+	ss.cleanup()
+}
+
+// Finish the death animation of all dying snakes and remove them.
+func (ss *Snakes) finishDying() {
+	for _, s := range ss.items {
+		if s.life == dying {
+			s.deathTimer = 0
+		}
+	}
+	ss.cleanup()
+}
+
+func TestTwoBitersDieOnSameFrame(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	victim := testSnakeH(180, 80, 11)
+	victim.score.val = 5
+	a := testSnakeV(60, 80, 4)
+	b := testSnakeV(120, 80, 4)
+	ss := &Snakes{items: []*Snake{victim, a, b}}
+
+	ss.collide()
+	if len(ss.bites) != 2 {
+		t.Fatalf("want 2 bites, got %d", len(ss.bites))
+	}
+	if len(ss.items) != 3 {
+		t.Fatalf("dying snakes must stay in the list, got %d snakes", len(ss.items))
+	}
+	if a.life != dying || b.life != dying || victim.life != alive {
+		t.Fatalf("unexpected lifecycle: victim=%d a=%d b=%d", victim.life, a.life, b.life)
+	}
+	if a.score.death != deathBitOther || b.score.death != deathBitOther {
+		t.Fatalf("unexpected death reasons: a=%d b=%d", a.score.death, b.score.death)
+	}
+	// The bites cut the victim's body.
+	if victim.body.len >= 11 {
+		t.Fatalf("the victim body must be cut, got %d points", victim.body.len)
+	}
+
+	ss.finishDying()
+	if len(ss.items) != 1 || ss.items[0] != victim {
+		t.Fatalf("only the victim must be left, got %d snakes", len(ss.items))
+	}
+	if len(ss.lost) != 2 {
+		t.Fatalf("want 2 lost players, got %d", len(ss.lost))
+	}
+	if a.life != removed || b.life != removed {
+		t.Fatalf("dead snakes must be removed: a=%d b=%d", a.life, b.life)
+	}
+	if !ss.gameOver() {
+		t.Fatal("the game must be over when only one player is left")
+	}
+	if title == nil || !title.blocking {
+		t.Fatal("the game over title must be shown")
+	}
+}
+
+func TestHeadOnBothDie(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	a := testSnakeH(100, 80, 3)
+	b := testSnake(firefly.P(102, 80), firefly.P(116, 80), firefly.P(130, 80))
+	ss := &Snakes{items: []*Snake{a, b}}
+
+	ss.collide()
+	if len(ss.bites) != 1 || !ss.bites[0].headOn {
+		t.Fatalf("want one head-on collision, got %v", ss.bites)
+	}
+	if a.life != dying || b.life != dying {
+		t.Fatalf("both snakes must die: a=%d b=%d", a.life, b.life)
+	}
+	ss.finishDying()
+	if len(ss.items) != 0 {
+		t.Fatalf("no snakes must be left, got %d", len(ss.items))
+	}
+	if !ss.gameOver() {
+		t.Fatal("the game must be over")
+	}
+}
+
+func TestHeadOnBiggerWinsIsSymmetric(t *testing.T) {
+	for _, swap := range []bool{false, true} {
+		setupCollisions(t, headOnBiggerWins)
+		small := testSnakeH(100, 80, 3)
+		big := testSnake(firefly.P(102, 80), firefly.P(116, 80), firefly.P(130, 80))
+		small.score.val = 1
+		big.score.val = 2
+		items := []*Snake{small, big}
+		if swap {
+			items = []*Snake{big, small}
+		}
+		ss := &Snakes{items: items}
+
+		ss.collide()
+		if small.life != dying {
+			t.Fatalf("swap=%v: the smaller snake must die", swap)
+		}
+		if big.life != alive || big.score.val != 2 {
+			t.Fatalf("swap=%v: the bigger snake must not be hurt", swap)
+		}
+	}
+}
+
+func TestHeadOnBiggerWinsTieHurtsBoth(t *testing.T) {
+	for _, swap := range []bool{false, true} {
+		setupCollisions(t, headOnBiggerWins)
+		a := testSnakeH(100, 80, 3)
+		b := testSnake(firefly.P(102, 80), firefly.P(116, 80), firefly.P(130, 80))
+		a.score.val = 2
+		b.score.val = 2
+		items := []*Snake{a, b}
+		if swap {
+			items = []*Snake{b, a}
+		}
+		ss := &Snakes{items: items}
+
+		ss.collide()
+		if a.score.val != 1 || b.score.val != 1 {
+			t.Fatalf("swap=%v: on a tie both snakes must be hurt, got %d and %d", swap, a.score.val, b.score.val)
+		}
+	}
+}
+
+func TestSplitHalvesDieTogether(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	victim := testSnakeH(180, 80, 11)
+	victim.score.val = 5
+	front := testSnakeV(60, 80, 4)
+	back := testSnakeV(120, 80, 4)
+	back.score = front.score
+	ss := &Snakes{items: []*Snake{victim, front, back}}
+
+	ss.collide()
+	if front.life != dying || back.life != dying {
+		t.Fatalf("both halves must die: front=%d back=%d", front.life, back.life)
+	}
+	ss.finishDying()
+	if len(ss.lost) != 1 {
+		t.Fatalf("the player must lose only once, got %d", len(ss.lost))
+	}
+	if ss.players() != 1 {
+		t.Fatalf("want 1 player left, got %d", ss.players())
+	}
+}
+
+func TestMergeRemovesAtCleanup(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	front := testSnakeH(100, 80, 3)
+	front.active = true
+	back := testSnakeH(70, 80, 3)
+	back.score = front.score
+	ss := &Snakes{items: []*Snake{front, back}}
+
+	ss.merge()
+	if back.life != removed {
+		t.Fatal("the attached half must be marked as removed")
+	}
+	if len(ss.items) != 2 {
+		t.Fatal("the list of snakes must not change before the cleanup")
+	}
+	if front.body.len != 6 || !front.active {
+		t.Fatalf("the back half must be attached to the front one, got %d points", front.body.len)
+	}
+	ss.cleanup()
+	if len(ss.items) != 1 || ss.items[0] != front {
+		t.Fatalf("only the front half must be left, got %d snakes", len(ss.items))
+	}
+	if len(ss.lost) != 0 {
+		t.Fatal("merging is not losing")
+	}
+}
+
+func TestTimeUpEndsRespawnMatch(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	rules.respawn = true
+	oldHUD, oldTicks := hud, ticks
+	t.Cleanup(func() { hud, ticks = oldHUD, oldTicks })
+
+	peers := firefly.Peers(0b111).Slice()
+	cases := []struct {
+		name string
+		best []int16
+		want Msg
+	}{
+		{"highest wins", []int16{7, 5, 6}, msgWin},
+		{"lower loses", []int16{5, 7, 6}, msgLose},
+		{"tie for the top", []int16{7, 7, 6}, msgTimeUp},
+		{"tie below the top", []int16{6, 7, 7}, msgLose},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scores := make([]*Score, len(peers))
+			for i, peer := range peers {
+				scores[i] = &Score{peer: peer, best: c.best[i]}
+			}
+			hud = &HUD{scores: scores}
+			ss := &Snakes{}
+
+			title = nil
+			ticks = respawnMatchTime - 1
+			ss.checkTime()
+			if title != nil {
+				t.Fatalf("match ended before the time is up")
+			}
+
+			ticks = respawnMatchTime
+			ss.checkTime()
+			if title == nil || !title.blocking {
+				t.Fatalf("match didn't end when the time is up")
+			}
+			if title.msg != c.want {
+				t.Errorf("got message %d, want %d", title.msg, c.want)
+			}
+		})
+	}
+}
+
+func TestWallKillsInvincibleSnake(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	oldRun := run
+	t.Cleanup(func() { run = oldRun })
+	wall := Line{h: firefly.P(100, 40), t: firefly.P(100, 120)}
+	run = &Run{stage: &Stage{walls: []Line{wall}}}
+
+	s := testSnakeH(100, 80, 4)
+	s.score.val = 5
+	s.score.iframes = iFrames
+	missed := testSnakeH(60, 80, 3)
+	missed.score.val = 5
+	ss := &Snakes{items: []*Snake{s, missed}}
+	ss.hitWalls()
+	ss.cleanup()
+
+	if s.life != dying || s.score.death != deathHitWall {
+		t.Errorf("snake in the wall: life %d, death %d", s.life, s.score.death)
+	}
+	if missed.life != alive {
+		t.Errorf("snake away from the wall is hurt")
+	}
+}
+
+func TestDiedOnceStillWinsOnTime(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	rules.respawn = true
+	oldHUD, oldTicks := hud, ticks
+	t.Cleanup(func() { hud, ticks = oldHUD, oldTicks })
+
+	peers := firefly.Peers(0b11).Slice()
+	mine := &Score{peer: peers[0], best: 9}
+	other := &Score{peer: peers[1], best: 1}
+	hud = &HUD{scores: []*Score{mine, other}}
+
+	for _, waiting := range []bool{false, true} {
+		ticks = 0
+		title = nil
+		setTitle(msgLose, false)
+		ss := &Snakes{respawns: []Respawn{{score: mine, timer: 1}}}
+		if waiting {
+			ss.respawns[0].timer = respawnDelay
+		}
+		ss.respawn()
+		if !waiting && title != nil {
+			t.Errorf("the death title stays after respawn")
+		}
+
+		ticks = respawnMatchTime
+		ss.checkTime()
+		if title == nil || !title.blocking || title.msg != msgWin {
+			t.Errorf("waiting %v: got title %+v, want a blocking win", waiting, title)
+		}
+	}
+}
+
+func TestLongWallHitNearEnd(t *testing.T) {
+	setupCollisions(t, headOnBothHurt)
+	oldRun := run
+	t.Cleanup(func() { run = oldRun })
+	// The wall from stage 4, longer than half of the play field.
+	wall := Line{h: firefly.P(80, 50), t: firefly.P(80, 150)}
+	run = &Run{stage: &Stage{walls: []Line{wall}}}
+
+	for _, y := range []int{52, 100, 130, 140, 148} {
+		s := testSnakeH(82, y, 3)
+		if !run.hitsWall(s) {
+			t.Errorf("snake at y=%d passes through the wall", y)
+		}
+	}
+	for _, y := range []int{40, 160} {
+		s := testSnakeH(82, y, 3)
+		if run.hitsWall(s) {
+			t.Errorf("snake at y=%d hits the wall", y)
+		}
+	}
+}
+
+func TestCutDropsPelletPerSegment(t *testing.T) {
+	for _, i := range []int{0, 1, 2, 5, 8, 9} {
+		setupCollisions(t, headOnBothHurt)
+		rules.lengthScore = true
+		s := testSnakeH(180, 80, 10)
+		s.score.val = 10
+		before := len(s.shape) - 1
+
+		s.cut(i)
+		removed := before - (len(s.shape) - 1)
+		if i <= 1 && removed != before-1 {
+			t.Errorf("cut(%d) removed %d segments, want all but the neck", i, removed)
+		}
+		if got := len(pellets.items); got != removed {
+			t.Errorf("cut(%d) removed %d segments but dropped %d pellets", i, removed, got)
+		}
+		if lost := int(10 - s.score.val); lost != removed {
+			t.Errorf("cut(%d) removed %d segments but took %d points", i, removed, lost)
+		}
+	}
+}
@@ -3,8 +3,8 @@ package game
 import "github.com/firefly-zero/firefly-go/firefly"
 
 type Title struct {
-	// The text to display.
-	msg string
+	// The message to display.
+	msg Msg
 
 	// If the title screen is blocking, for how much longer it will be displayed.
 	// Non-blocking title screen is displayed until it is replaced by a blocking one.
@@ -14,27 +14,63 @@ type Title struct {
 	// Non-blocking title screen is shown only for one snake when it dies
 	// and it's rendered on the background instead of covering the whole screen.
 	blocking bool
+
+	// Banner is a short non-blocking message, like "level up".
+	// It disappears when its ttl runs out and any other title replaces it.
+	banner bool
+
+	// How long the game lasted, in frames.
+	duration int
+
+	// The buffer for formatting the game results.
+	//
+	// The longest line is "bestest 32767  mmm:ss  lvl 6/255" (32 bytes).
+	buf [32]byte
 }
 
-func setTitle(msg string, blocking bool) {
-	const defaultTTL = 240
+// For how long (in frames) the blocking title is shown.
+const titleTTL = 240
+
+// For how long (in frames) the banner is shown.
+const bannerTTL = 90
 
+func setTitle(msg Msg, blocking bool) {
 	// If a title is already set, keep it. This way we make sure that if a snake died,
 	// we keep the "you died" message instead of  replacing it with "you win" message.
-	if title != nil {
+	if title != nil && !title.banner {
 		// If the new title is blocking (the "game over" screen)
 		// show the blocking title but keep the text.
 		if blocking {
-			title.ttl = defaultTTL
+			stopMusic()
+			title.duration = ticks
+			title.ttl = titleTTL
 			title.blocking = true
 		}
 		return
 	}
 
+	if blocking {
+		stopMusic()
+	}
+	playSound(soundGameOver)
 	title = &Title{
 		msg:      msg,
-		ttl:      defaultTTL,
+		ttl:      titleTTL,
 		blocking: blocking,
+		duration: ticks,
+	}
+}
+
+// Show a short message on the background. Any other title has priority over it.
+func setBanner(msg Msg) {
+	if title != nil {
+		return
+	}
+	playSound(soundLevelUp)
+	title = &Title{
+		msg:    msg,
+		ttl:    bannerTTL,
+		banner: true,
 	}
 }
 
@@ -42,6 +78,10 @@ func (t *Title) update() {
 	if t.ttl >= 0 {
 		t.ttl--
 	}
+	if t.banner && t.ttl <= 0 {
+		title = nil
+		return
+	}
 	btns := firefly.ReadButtons(firefly.Combined)
 	if t.blocking && (btns.Any() || t.ttl <= 0) {
 		resetGame()
@@ -49,7 +89,62 @@ func (t *Title) update() {
 }
 
 func (t *Title) render() {
-	x := (firefly.Width - font.LineWidth(t.msg)) / 2
-	y := (firefly.Height + font.CharHeight()) / 2
-	firefly.DrawText(t.msg, font, firefly.P(x, y), firefly.ColorBlack)
+	const maxWidth = firefly.Width - 16
+	lineHeight := font.CharHeight() + 2
+	msg := tr(t.msg)
+	subtitle := tr(msgPressAnyButton)
+
+	// Measure everything first to center the whole block vertically.
+	height := textHeight(msg, maxWidth, lineHeight)
+	if t.blocking {
+		height += lineHeight * 2
+		height += textHeight(subtitle, maxWidth, lineHeight)
+	}
+	y := (firefly.Height - height) / 2
+
+	y = drawWrapped(msg, y, maxWidth, lineHeight, firefly.ColorBlack)
+	if !t.blocking {
+		return
+	}
+	t.renderResults(y + lineHeight)
+	y += lineHeight * 2
+	drawWrapped(subtitle, y, maxWidth, lineHeight, firefly.ColorGray)
+	t.renderCountdown()
+}
+
+// Render a bar at the bottom of the screen showing
+// how long it is left before the game restarts.
+func (t *Title) renderCountdown() {
+	width := firefly.Width * max(t.ttl, 0) / titleTTL
+	firefly.DrawRect(
+		firefly.P((firefly.Width-width)/2, firefly.Height-4),
+		firefly.S(width, 4),
+		firefly.Solid(firefly.ColorLightGray),
+	)
+}
+
+// Render the best score of the player and how long the game lasted.
+func (t *Title) renderResults(y int) {
+	line := append(t.buf[:0], tr(msgBest)...)
+	line = append(line, ' ')
+	line = appendInt(line, int(hud.myScore().best), 0, false)
+	line = append(line, "  "...)
+	line = appendTime(line, t.duration)
+	if run != nil {
+		line = append(line, "  "...)
+		line = appendStage(line, run.index)
+	} else if !isMultiplayer && rules.challenge != 0 {
+		line = append(line, "  "...)
+		line = appendChallenge(line, rules.challenge)
+	} else if !isMultiplayer {
+		line = append(line, "  "...)
+		line = append(line, tr(msgLevel)...)
+		line = append(line, ' ')
+		line = appendInt(line, level, 0, false)
+		// The best level ever reached, saved across games.
+		line = append(line, '/')
+		line = appendInt(line, max(bestLevel, level), 0, false)
+	}
+	x := (firefly.Width - font.CharWidth()*len(line)) / 2
+	font.DrawBytes(line, firefly.P(x, y), firefly.ColorGray)
 }
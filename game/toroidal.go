@@ -0,0 +1,103 @@
+package game
+
+import "github.com/firefly-zero/firefly-go/firefly"
+
+// The play field wraps around: what goes out from one edge comes back from the opposite one.
+// So the game world is a torus and there are always two ways to get
+// from one point to another along each axis. The functions below pick the shortest one.
+//
+// The play field is the part of the screen below the HUD, so that snakes
+// never move under the HUD where the player can't see them.
+
+// The height of the play field, from the HUD to the bottom of the screen.
+const fieldHeight = firefly.Height - hudHeight
+
+// Get the shortest vector from a to b.
+func wrapDelta(a, b firefly.Point) firefly.Point {
+	d := b.Sub(a)
+	return firefly.P(
+		wrapAxis(d.X, firefly.Width),
+		wrapAxis(d.Y, fieldHeight),
+	)
+}
+
+// Bring the distance along one axis into the [-size/2, size/2) range.
+func wrapAxis(d, size int) int {
+	d %= size
+	if d >= size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
+
+// Shift the point by the play field size so that it's as close as possible to the reference point.
+//
+// The result may be outside of the play field.
+func nearest(p, ref firefly.Point) firefly.Point {
+	return ref.Add(wrapDelta(ref, p))
+}
+
+// Shift the line by the play field size so that its start is as close as possible to the reference point.
+func lineNear(l Line, ref firefly.Point) Line {
+	shift := nearest(l.h, ref).Sub(l.h)
+	return Line{l.h.Add(shift), l.t.Add(shift)}
+}
+
+// Get the line from a to b going the shortest way.
+//
+// The line starts at a, the end might be outside of the play field.
+func wrappedLine(a, b firefly.Point) Line {
+	return Line{a, a.Add(wrapDelta(a, b))}
+}
+
+// Get the point on the shortest way from a to b at the given fraction (num/den) of the way.
+func lerpPoint(a, b firefly.Point, num, den int) firefly.Point {
+	d := wrapDelta(a, b)
+	return firefly.P(
+		normalizeX(a.X+d.X*num/den),
+		normalizeY(a.Y+d.Y*num/den),
+	)
+}
+
+// Render something at every position where it's visible on the wrapped play field.
+//
+// The min and max are the corners of the bounding box of what is rendered.
+// The draw callback is called for every copy of the box shifted by the play field size
+// which is at least partially visible, and accepts the shift to apply to all coordinates.
+// This way, things crossing an edge are rendered on both sides of the play field.
+func drawToroidal(min, max firefly.Point, draw func(shift firefly.Point)) {
+	for _, dx := range [...]int{0, -firefly.Width, firefly.Width} {
+		if max.X+dx < 0 || min.X+dx >= firefly.Width {
+			continue
+		}
+		for _, dy := range [...]int{0, -fieldHeight, fieldHeight} {
+			if max.Y+dy < hudHeight || min.Y+dy >= firefly.Height {
+				continue
+			}
+			draw(firefly.P(dx, dy))
+		}
+	}
+}
+
+// Render something around the given center at every position where it's visible.
+//
+// The radius is how far from the center the rendered thing reaches.
+func drawToroidalAround(center firefly.Point, radius int, draw func(shift firefly.Point)) {
+	r := firefly.P(radius, radius)
+	drawToroidal(center.Sub(r), center.Add(r), draw)
+}
+
+// Render the text at every position where it's visible.
+//
+// The point is the same as for [firefly.Font.Draw]: the left side of the text baseline.
+func drawToroidalText(text string, p firefly.Point, c firefly.Color) {
+	drawToroidal(
+		firefly.P(p.X, p.Y-font.CharHeight()),
+		firefly.P(p.X+font.LineWidth(text), p.Y),
+		func(shift firefly.Point) {
+			font.Draw(text, p.Add(shift), c)
+		},
+	)
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/firefly-zero/firefly-go/firefly"
+)
+
+// The coordinates of the edges of the play field.
+const (
+	edgeLeft   = 0
+	edgeRight  = firefly.Width - 1
+	edgeTop    = hudHeight
+	edgeBottom = firefly.Height - 1
+)
+
+func TestWrapDelta(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b firefly.Point
+		want firefly.Point
+	}{
+		{"same point", firefly.P(50, 50), firefly.P(50, 50), firefly.P(0, 0)},
+		{"direct", firefly.P(10, 20), firefly.P(30, 50), firefly.P(20, 30)},
+		{"direct back", firefly.P(30, 50), firefly.P(10, 20), firefly.P(-20, -30)},
+		{"across right edge", firefly.P(edgeRight, 50), firefly.P(edgeLeft, 50), firefly.P(1, 0)},
+		{"across left edge", firefly.P(edgeLeft, 50), firefly.P(edgeRight, 50), firefly.P(-1, 0)},
+		{"across bottom edge", firefly.P(50, edgeBottom), firefly.P(50, edgeTop), firefly.P(0, 1)},
+		{"across top edge", firefly.P(50, edgeTop), firefly.P(50, edgeBottom), firefly.P(0, -1)},
+		{"across corner", firefly.P(edgeRight, edgeBottom), firefly.P(edgeLeft+2, edgeTop+3), firefly.P(3, 4)},
+		{"half width away", firefly.P(0, 50), firefly.P(firefly.Width/2, 50), firefly.P(-firefly.Width/2, 0)},
+		{"half width away back", firefly.P(firefly.Width/2, 50), firefly.P(0, 50), firefly.P(-firefly.Width/2, 0)},
+		{"half height away", firefly.P(50, edgeTop), firefly.P(50, edgeTop+fieldHeight/2), firefly.P(0, -fieldHeight/2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapDelta(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("wrapDelta(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		name   string
+		p, ref firefly.Point
+		want   firefly.Point
+	}{
+		{"already near", firefly.P(60, 60), firefly.P(50, 50), firefly.P(60, 60)},
+		{"right of the right edge", firefly.P(edgeLeft+3, 50), firefly.P(edgeRight, 50), firefly.P(firefly.Width+3, 50)},
+		{"left of the left edge", firefly.P(edgeRight-3, 50), firefly.P(edgeLeft, 50), firefly.P(-4, 50)},
+		{"below the bottom edge", firefly.P(50, edgeTop+3), firefly.P(50, edgeBottom), firefly.P(50, edgeBottom+4)},
+		{"above the top edge", firefly.P(50, edgeBottom-3), firefly.P(50, edgeTop), firefly.P(50, edgeTop-4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearest(tt.p, tt.ref)
+			if got != tt.want {
+				t.Errorf("nearest(%v, %v) = %v, want %v", tt.p, tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineNear(t *testing.T) {
+	tests := []struct {
+		name string
+		l    Line
+		ref  firefly.Point
+		want Line
+	}{
+		{
+			"already near",
+			Line{firefly.P(50, 50), firefly.P(60, 50)},
+			firefly.P(55, 55),
+			Line{firefly.P(50, 50), firefly.P(60, 50)},
+		},
+		{
+			"shifted right",
+			Line{firefly.P(edgeLeft+2, 50), firefly.P(edgeLeft+12, 50)},
+			firefly.P(edgeRight, 50),
+			Line{firefly.P(firefly.Width+2, 50), firefly.P(firefly.Width+12, 50)},
+		},
+		{
+			"shifted up",
+			Line{firefly.P(50, edgeBottom-2), firefly.P(50, edgeBottom-12)},
+			firefly.P(50, edgeTop),
+			Line{firefly.P(50, edgeBottom-2-fieldHeight), firefly.P(50, edgeBottom-12-fieldHeight)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lineNear(tt.l, tt.ref)
+			if got != tt.want {
+				t.Errorf("lineNear(%v, %v) = %v, want %v", tt.l, tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrappedLine(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b firefly.Point
+		want Line
+	}{
+		{"direct", firefly.P(10, 20), firefly.P(30, 20), Line{firefly.P(10, 20), firefly.P(30, 20)}},
+		{"across right edge", firefly.P(edgeRight-4, 50), firefly.P(edgeLeft+5, 50), Line{firefly.P(edgeRight-4, 50), firefly.P(firefly.Width+5, 50)}},
+		{"across left edge", firefly.P(edgeLeft+4, 50), firefly.P(edgeRight-5, 50), Line{firefly.P(edgeLeft+4, 50), firefly.P(-6, 50)}},
+		{"across bottom edge", firefly.P(50, edgeBottom), firefly.P(50, edgeTop+9), Line{firefly.P(50, edgeBottom), firefly.P(50, edgeBottom+10)}},
+		{"across top edge", firefly.P(50, edgeTop), firefly.P(50, edgeBottom-9), Line{firefly.P(50, edgeTop), firefly.P(50, edgeTop-10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrappedLine(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("wrappedLine(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}